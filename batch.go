@@ -0,0 +1,79 @@
+// Copyright (c) 2025-2026 complex (complex@ft.hn)
+// See LICENSE for licensing information
+
+package polyseed
+
+import (
+	"bufio"
+	"io"
+	"strings"
+
+	"github.com/complex-gh/polyseed_go/lang"
+)
+
+// batchMaxLine is the size of the line buffer of DecodeBatch. A line that
+// does not fit, including its line ending, is far longer than any phrase.
+const batchMaxLine = 4096
+
+// BatchResult is the result of decoding one line with DecodeBatch
+type BatchResult struct {
+	// Line is the 1-based line number in the input
+	Line int
+
+	// Seed is the decoded seed, or nil if decoding failed. The caller owns
+	// it and should Free it when done.
+	Seed *Seed
+
+	// Lang is the detected language of the phrase
+	Lang *lang.Language
+
+	// Err is the decoding error of the line
+	Err error
+}
+
+// DecodeBatch reads mnemonic phrases from r, one per line, and decodes
+// each of them like Decode. Lines that are empty or contain only white
+// space are skipped. A line that fails to decode does not stop the batch;
+// its error is reported in its result, and lines of 4096 bytes or more are
+// reported with ErrLineTooLong.
+//
+// The phrases are copied into strings and read buffers that cannot be
+// erased, so they stay in memory until the garbage collector reuses it.
+//
+// Returns the results in input order, and an error if reading from r
+// failed. The results of the lines read before the failure are returned
+// as well.
+func DecodeBatch(r io.Reader, coin Coin) ([]BatchResult, error) {
+	var results []BatchResult
+
+	br := bufio.NewReaderSize(r, batchMaxLine)
+	for line := 1; ; line++ {
+		data, err := br.ReadSlice('\n')
+		switch err {
+		case bufio.ErrBufferFull:
+			// Skip the rest of the line
+			for err == bufio.ErrBufferFull {
+				_, err = br.ReadSlice('\n')
+			}
+			results = append(results, BatchResult{Line: line, Err: ErrLineTooLong})
+		case nil, io.EOF:
+			text := strings.TrimSuffix(strings.TrimSuffix(string(data), "\n"), "\r")
+			if strings.TrimSpace(text) == "" {
+				break
+			}
+			seed, foundLang, decodeErr := Decode(text, coin)
+			results = append(results, BatchResult{
+				Line: line,
+				Seed: seed,
+				Lang: foundLang,
+				Err:  decodeErr,
+			})
+		}
+		if err == io.EOF {
+			return results, nil
+		}
+		if err != nil {
+			return results, err
+		}
+	}
+}
@@ -3,6 +3,10 @@
 
 package polyseed
 
+import (
+	"time"
+)
+
 const (
 	// epoch is the base timestamp: 1st November 2021 12:00 UTC
 	epoch = uint64(1635768000)
@@ -31,3 +35,76 @@ func birthdayDecode(birthday uint16) uint64 {
 	return epoch + uint64(birthday)*timeStep
 }
 
+// EncodeBirthday converts a Unix timestamp to the 10-bit birthday value
+// stored in a seed. Timestamps before the polyseed epoch are encoded as 0.
+func EncodeBirthday(timestamp uint64) uint16 {
+	return birthdayEncode(timestamp)
+}
+
+// EncodeBirthdayChecked converts a Unix timestamp to a birthday value like
+// EncodeBirthday. ok is false if the timestamp is too far past the epoch
+// (around the year 2106) to be represented, in which case the returned
+// value has wrapped around to an earlier date.
+func EncodeBirthdayChecked(timestamp uint64) (uint16, bool) {
+	b := birthdayEncode(timestamp)
+	if timestamp != ^uint64(0) && timestamp >= epoch && (timestamp-epoch)/timeStep > DateMask {
+		return b, false
+	}
+	return b, true
+}
+
+// DecodeBirthday converts a 10-bit birthday value to a Unix timestamp
+func DecodeBirthday(b uint16) uint64 {
+	return birthdayDecode(b)
+}
+
+// SetBirthday changes the creation date of the seed to timestamp and
+// recomputes the checksum, so the seed stays valid. It is meant for
+// correcting a wrong birthday, for example after an import. Timestamps
+// before the polyseed epoch are stored as the epoch.
+//
+// The birthday is part of the Keygen salt, so the derived keys change too.
+// Returns ErrBirthday if the timestamp cannot be represented, in which case
+// the seed is not changed.
+func (s *Seed) SetBirthday(timestamp uint64) error {
+	birthday, ok := EncodeBirthdayChecked(timestamp)
+	if !ok {
+		return ErrBirthday
+	}
+	s.birthday = birthday
+	s.updateChecksum()
+	return nil
+}
+
+// BirthdayTime gets the approximate date when the seed was created
+func (s *Seed) BirthdayTime() time.Time {
+	return time.Unix(int64(birthdayDecode(s.birthday)), 0).UTC()
+}
+
+// BirthdayRange gets the half-open interval [start, end) in which the seed
+// was created. The birthday is stored with a resolution of about 30 days.
+func (s *Seed) BirthdayRange() (start, end time.Time) {
+	start = s.BirthdayTime()
+	end = start.Add(time.Duration(timeStep) * time.Second)
+	return start, end
+}
+
+// birthdayLabelFormat formats the middle of the birthday interval as the
+// label returned by BirthdayLabel
+var birthdayLabelFormat = englishBirthdayLabel
+
+// englishBirthdayLabel formats a birthday as an English month and year,
+// e.g. "November 2021"
+func englishBirthdayLabel(t time.Time) string {
+	return t.Format("January 2006")
+}
+
+// BirthdayLabel gets the month when the seed was created as a label for
+// display, e.g. "November 2021". The birthday is stored with a resolution
+// of about 30 days, which does not line up exactly with calendar months,
+// so the label names the month that contains most of BirthdayRange and
+// has no day. Each stored value maps to a different month.
+func (s *Seed) BirthdayLabel() string {
+	start, end := s.BirthdayRange()
+	return birthdayLabelFormat(start.Add(end.Sub(start) / 2))
+}
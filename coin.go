@@ -0,0 +1,102 @@
+// Copyright (c) 2025-2026 complex (complex@ft.hn)
+// See LICENSE for licensing information
+
+package polyseed
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/complex-gh/polyseed_go/internal"
+	"github.com/complex-gh/polyseed_go/lang"
+)
+
+// knownCoins lists the coins tried by DecodeAnyCoin
+var knownCoins = []Coin{CoinMonero, CoinAeon, CoinWownero}
+
+// DecodeAnyCoin decodes the seed from a mnemonic phrase for an unknown coin.
+// Each known coin is tried and the one whose checksum validates is returned.
+//
+// The coin is added to a single coefficient of the polynomial, so at most
+// one coin can satisfy the checksum of a given phrase.
+func DecodeAnyCoin(str string) (*Seed, *lang.Language, Coin, error) {
+	var lastErr error
+	for _, coin := range knownCoins {
+		seed, foundLang, err := Decode(str, coin)
+		if err == nil {
+			return seed, foundLang, coin, nil
+		}
+		if err != StatusErrChecksum && err != StatusErrUnsupported {
+			return nil, nil, 0, err
+		}
+		// Unsupported features mean the checksum matched this coin
+		if lastErr != StatusErrUnsupported {
+			lastErr = err
+		}
+	}
+	return nil, nil, 0, lastErr
+}
+
+var (
+	// coinNamesMu guards coinNames
+	coinNamesMu sync.RWMutex
+
+	// coinNames maps coins to their names
+	coinNames = map[Coin]string{
+		CoinMonero:  "Monero",
+		CoinAeon:    "Aeon",
+		CoinWownero: "Wownero",
+	}
+)
+
+// String returns the name of the coin, or "Coin(N)" for an unknown coin
+func (c Coin) String() string {
+	coinNamesMu.RLock()
+	defer coinNamesMu.RUnlock()
+	if name, ok := coinNames[c]; ok {
+		return name
+	}
+	return fmt.Sprintf("Coin(%d)", uint16(c))
+}
+
+// RegisterCoin assigns a name to a custom coin, so that it is recognized by
+// Coin.String and ParseCoin.
+//
+// Returns an error wrapping ErrCoin if the coin is out of range, or if the
+// coin or the name is already registered.
+func RegisterCoin(c Coin, name string) error {
+	if c > internal.GfMask {
+		return fmt.Errorf("%w: coin %d is out of range", ErrCoin, uint16(c))
+	}
+	if name == "" {
+		return fmt.Errorf("%w: empty name", ErrCoin)
+	}
+	coinNamesMu.Lock()
+	defer coinNamesMu.Unlock()
+	for other, otherName := range coinNames {
+		if other == c {
+			return fmt.Errorf("%w: coin %d is already registered as %q", ErrCoin, uint16(c), otherName)
+		}
+		if strings.EqualFold(otherName, name) {
+			return fmt.Errorf("%w: name %q is already registered", ErrCoin, name)
+		}
+	}
+	coinNames[c] = name
+	return nil
+}
+
+// ParseCoin returns the coin with the given name. The comparison is
+// case-insensitive.
+//
+// Returns ErrCoin if no coin has the name.
+func ParseCoin(s string) (Coin, error) {
+	coinNamesMu.RLock()
+	defer coinNamesMu.RUnlock()
+	for c, name := range coinNames {
+		if strings.EqualFold(name, s) {
+			return c, nil
+		}
+	}
+	return 0, ErrCoin
+}
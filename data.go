@@ -0,0 +1,51 @@
+// Copyright (c) 2025-2026 complex (complex@ft.hn)
+// See LICENSE for licensing information
+
+package polyseed
+
+// SeedData mirrors the polyseed_data struct of the reference C library,
+// for moving seeds across FFI boundaries. Like the seed itself, it holds
+// the secret and must be handled as sensitive.
+type SeedData struct {
+	// Birthday is the encoded creation date, see EncodeBirthday
+	Birthday uint16
+
+	// Features are the feature bits, including the encryption flag
+	Features uint8
+
+	// Secret holds the 150-bit secret in its first 19 bytes; the other
+	// bytes are zero
+	Secret [32]byte
+
+	// Checksum is the 11-bit checksum, the first word of the phrase
+	Checksum uint16
+}
+
+// Data returns the fields of the seed. The caller owns the copy of the
+// secret and should erase it when done.
+func (s *Seed) Data() SeedData {
+	return SeedData{
+		Birthday: s.birthday,
+		Features: s.features,
+		Secret:   s.secret,
+		Checksum: s.checksum,
+	}
+}
+
+// SeedDataToSeed creates a seed from its fields, as returned by Data.
+//
+// Returns the seed and the error of Seed.Validate if the fields do not
+// form a valid seed.
+func SeedDataToSeed(d SeedData) (*Seed, error) {
+	seed := &Seed{
+		birthday: d.Birthday,
+		features: d.Features,
+		secret:   d.Secret,
+		checksum: d.Checksum,
+	}
+	if err := seed.Validate(); err != nil {
+		seed.Free()
+		return nil, err
+	}
+	return trackSeed(seed), nil
+}
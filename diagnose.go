@@ -0,0 +1,109 @@
+// Copyright (c) 2025-2026 complex (complex@ft.hn)
+// See LICENSE for licensing information
+
+package polyseed
+
+import (
+	"github.com/complex-gh/polyseed_go/lang"
+)
+
+// LangReport describes how a phrase failed to match a language
+type LangReport struct {
+	// Lang is the attempted language
+	Lang *lang.Language
+
+	// WordIndex is the position of the first unrecognized word
+	WordIndex int
+
+	// Word is the first unrecognized word
+	Word string
+}
+
+// WordLangs lists the languages that recognize a word of a phrase
+type WordLangs struct {
+	// Word is the word as it appears in the split phrase
+	Word string
+
+	// Langs are the languages whose wordlist contains the word, in index
+	// order. It is empty if no language recognizes the word.
+	Langs []*lang.Language
+}
+
+// DecodeReport describes why a phrase could not be decoded
+type DecodeReport struct {
+	// Langs has one entry per attempted language
+	Langs []LangReport
+
+	// Words has one entry per word of the phrase
+	Words []WordLangs
+}
+
+// Mixed reports whether every word of the phrase is recognized by some
+// language, but no single language recognizes them all. This is the
+// typical result of joining parts of phrases in different languages; the
+// Words entries tell which part belongs to which language.
+func (r *DecodeReport) Mixed() bool {
+	if len(r.Words) == 0 {
+		return false
+	}
+	counts := make(map[*lang.Language]int)
+	for _, w := range r.Words {
+		if len(w.Langs) == 0 {
+			return false
+		}
+		for _, l := range w.Langs {
+			counts[l]++
+		}
+	}
+	for _, n := range counts {
+		if n == len(r.Words) {
+			return false
+		}
+	}
+	return true
+}
+
+// CheckPhraseLanguages splits a mnemonic phrase and reports, for each word,
+// which languages recognize it. The number of words is not checked.
+func CheckPhraseLanguages(str string) []WordLangs {
+	words := SplitPhrase(str)
+	report := make([]WordLangs, len(words))
+	for j, word := range words {
+		report[j].Word = word
+		for i := 0; i < GetNumLangs(); i++ {
+			if l := GetLang(i); l.FindWord(word) >= 0 {
+				report[j].Langs = append(report[j].Langs, l)
+			}
+		}
+	}
+	return report
+}
+
+// DecodeVerbose decodes the seed from a mnemonic phrase like Decode. If no
+// language recognizes all words, it also returns a report listing the first
+// unrecognized word for each language and the languages that recognize each
+// word, which reveals phrases that mix languages.
+func DecodeVerbose(str string, coin Coin) (*Seed, *lang.Language, *DecodeReport, error) {
+	seed, foundLang, err := Decode(str, coin)
+	if err != StatusErrLang {
+		return seed, foundLang, nil, err
+	}
+
+	words := SplitPhrase(str)
+	report := &DecodeReport{Words: CheckPhraseLanguages(str)}
+	for i := 0; i < GetNumLangs(); i++ {
+		l := GetLang(i)
+		for j, word := range words {
+			if l.FindWord(word) < 0 {
+				report.Langs = append(report.Langs, LangReport{
+					Lang:      l,
+					WordIndex: j,
+					Word:      word,
+				})
+				break
+			}
+		}
+	}
+
+	return nil, nil, report, err
+}
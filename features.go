@@ -3,6 +3,16 @@
 
 package polyseed
 
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/complex-gh/polyseed_go/internal"
+	"github.com/complex-gh/polyseed_go/lang"
+)
+
 const (
 	// FeatureBits is the total number of feature bits
 	FeatureBits = 5
@@ -23,6 +33,20 @@ const (
 	encryptedMask = 16
 )
 
+// Encryption schemes returned by Seed.EncryptionScheme
+const (
+	// EncryptionNone indicates an unencrypted seed
+	EncryptionNone = 0
+
+	// EncryptionPassword indicates a seed encrypted by a passphrase with
+	// Crypt
+	EncryptionPassword = 1
+
+	// EncryptionUnknown indicates internal feature bits that this version
+	// does not understand, possibly a newer encryption scheme
+	EncryptionUnknown = -1
+)
+
 // makeFeatures creates a feature value from user features
 func makeFeatures(userFeatures uint8) uint8 {
 	return userFeatures & userFeaturesMask
@@ -38,9 +62,57 @@ func isEncrypted(features uint8) bool {
 	return (features & encryptedMask) != 0
 }
 
+var (
+	// featuresMu guards the default feature set
+	featuresMu sync.RWMutex
+
+	// reservedFeatures tracks which feature bits are reserved
+	reservedFeatures uint8 = FeatureMask ^ encryptedMask
+)
+
+// FeatureSet is a set of enabled seed features. Unlike EnableFeatures, it
+// is a plain value, so different feature sets can be used concurrently.
+type FeatureSet struct {
+	reserved uint8
+}
+
+// NewFeatureSet creates a feature set with the optional seed features
+// enabled. Up to 3 different boolean flags are supported.
+//
+// mask is a bitmask of the enabled features. Only the least significant 3 bits are used.
+func NewFeatureSet(mask uint8) FeatureSet {
+	fs := FeatureSet{reserved: FeatureMask ^ encryptedMask}
+	fs.reserved ^= mask & userFeaturesMask
+	return fs
+}
+
+// defaultFeatureSet returns the feature set configured by EnableFeatures
+func defaultFeatureSet() FeatureSet {
+	featuresMu.RLock()
+	defer featuresMu.RUnlock()
+	return FeatureSet{reserved: reservedFeatures}
+}
+
+// supported checks if the given features are supported
+func (fs FeatureSet) supported(features uint8) bool {
+	return (features & fs.reserved) == 0
+}
+
+// Create creates a new seed with specific features, which must be enabled
+// in the feature set. See Create.
+func (fs FeatureSet) Create(features uint8) (*Seed, error) {
+	return createFromReader(rand.Reader, features, fs)
+}
+
+// Decode decodes the seed from a mnemonic phrase, accepting the features
+// enabled in the feature set. See Decode.
+func (fs FeatureSet) Decode(str string, coin Coin) (*Seed, *lang.Language, error) {
+	return decode(str, coin, fs)
+}
+
 // featuresSupported checks if the given features are supported
 func featuresSupported(features uint8) bool {
-	return (features & reservedFeatures) == 0
+	return defaultFeatureSet().supported(features)
 }
 
 // EnableFeatures enables the optional seed features. Up to 3 different boolean flags are
@@ -49,7 +121,13 @@ func featuresSupported(features uint8) bool {
 // mask is a bitmask of the enabled features. Only the least significant 3 bits are used.
 //
 // Returns the number of features that were enabled (0, 1, 2 or 3).
+//
+// Deprecated: EnableFeatures changes the features for the whole process.
+// Use a FeatureSet instead.
 func EnableFeatures(mask uint8) int {
+	featuresMu.Lock()
+	defer featuresMu.Unlock()
+
 	numEnabled := 0
 	reservedFeatures = FeatureMask ^ encryptedMask
 	for i := 0; i < userFeatures; i++ {
@@ -62,3 +140,85 @@ func EnableFeatures(mask uint8) int {
 	return numEnabled
 }
 
+// EnableFeaturesChecked enables the optional seed features like
+// EnableFeatures, but rejects masks with bits other than the 3 user
+// features instead of ignoring them. Like EnableFeatures, it changes the
+// features for the whole process; a FeatureSet is preferred.
+//
+// Returns an error wrapping StatusErrUnsupported if mask has reserved bits,
+// in which case the enabled features are not changed.
+func EnableFeaturesChecked(mask uint8) error {
+	if reserved := mask &^ userFeaturesMask; reserved != 0 {
+		return fmt.Errorf("%w: reserved feature bits %#02x", StatusErrUnsupported, reserved)
+	}
+	EnableFeatures(mask)
+	return nil
+}
+
+// FeatureMismatch explains a decode failure caused by unsupported features.
+//
+// decodeErr is the error returned by Decode for phrase. If it is
+// StatusErrUnsupported, the phrase is decoded again to find the feature bits
+// it uses that are not enabled locally.
+//
+// Returns the user feature bits that must be enabled with EnableFeatures to
+// decode the phrase. ok is false if decodeErr is not StatusErrUnsupported or
+// the phrase uses reserved bits that cannot be enabled.
+func FeatureMismatch(decodeErr error, phrase string, coin Coin) (missing uint8, ok bool) {
+	if !errors.Is(decodeErr, StatusErrUnsupported) {
+		return 0, false
+	}
+
+	p, _, err := phraseToPoly(phrase, coin)
+	if err != nil {
+		return 0, false
+	}
+	defer putPoly(p)
+	if !p.Check() {
+		return 0, false
+	}
+
+	features, _ := internal.PolyMeta(p)
+	unsupported := features & defaultFeatureSet().reserved
+	missing = unsupported & userFeaturesMask
+	return missing, missing != 0 && missing == unsupported
+}
+
+var (
+	// featureNamesMu guards featureNames
+	featureNamesMu sync.RWMutex
+
+	// featureNames maps user feature bits to names
+	featureNames = map[uint8]string{}
+)
+
+// RegisterFeatureName assigns a name to a user feature. bit is the index of
+// the feature (0, 1 or 2). Other values are ignored.
+func RegisterFeatureName(bit uint8, name string) {
+	if bit >= userFeatures {
+		return
+	}
+	featureNamesMu.Lock()
+	defer featureNamesMu.Unlock()
+	featureNames[bit] = name
+}
+
+// FeatureNames returns the names of the user features set on the seed.
+// Features without a registered name are reported as "feature N".
+func (s *Seed) FeatureNames() []string {
+	featureNamesMu.RLock()
+	defer featureNamesMu.RUnlock()
+
+	var names []string
+	for bit := uint8(0); bit < userFeatures; bit++ {
+		if !s.HasFeature(1 << bit) {
+			continue
+		}
+		name, ok := featureNames[bit]
+		if !ok {
+			name = fmt.Sprintf("feature %d", bit)
+		}
+		names = append(names, name)
+	}
+	return names
+}
@@ -6,3 +6,5 @@ require (
 	golang.org/x/crypto v0.46.0
 	golang.org/x/text v0.32.0
 )
+
+require golang.org/x/sys v0.39.0 // indirect
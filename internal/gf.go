@@ -42,6 +42,16 @@ const (
 	FeatureMask = (1 << FeatureBits) - 1
 )
 
+// PackMeta packs the features and birthday into a single 15-bit value
+func PackMeta(features uint8, birthday uint16) uint16 {
+	return uint16(features)<<DateBits | birthday&DateMask
+}
+
+// UnpackMeta splits a packed value into features and birthday
+func UnpackMeta(v uint16) (features uint8, birthday uint16) {
+	return uint8(v >> DateBits), v & DateMask
+}
+
 // gfElem represents an element in GF(2048)
 type GfElem uint16
 
@@ -80,9 +90,32 @@ func (p *GfPoly) Check() bool {
 	return p.Eval() == 0
 }
 
+// Solve sets coefficient i to the unique value that satisfies the checksum.
+// Every coefficient is covered by the checksum, so exactly one value exists.
+func (p *GfPoly) Solve(i int) bool {
+	for v := GfElem(0); v < GfSize; v++ {
+		p.Coeff[i] = v
+		if p.Check() {
+			return true
+		}
+	}
+	return false
+}
+
+// PolyMeta extracts the features and birthday from a polynomial without
+// decoding the secret
+func PolyMeta(p *GfPoly) (features uint8, birthday uint16) {
+	extraVal := uint16(0)
+	for i := PolyNumCheckDigits; i < NumWords; i++ {
+		extraVal <<= 1
+		extraVal |= uint16(p.Coeff[i]) & 1
+	}
+	return UnpackMeta(extraVal)
+}
+
 // DataToPoly converts seed data to a polynomial
 func DataToPoly(d *Data, p *GfPoly) {
-	extraVal := (uint32(d.Features) << DateBits) | uint32(d.Birthday)
+	extraVal := uint32(PackMeta(d.Features, d.Birthday))
 	extraBits := FeatureBits + DateBits
 
 	wordBits := 0
@@ -178,7 +211,6 @@ func PolyToData(p *GfPoly, d *Data) {
 
 	seedBits += secretBits
 
-	d.Birthday = uint16(extraVal & DateMask)
-	d.Features = uint8(extraVal >> DateBits)
+	d.Features, d.Birthday = UnpackMeta(uint16(extraVal))
 }
 
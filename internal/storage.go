@@ -32,6 +32,10 @@ func load16(p []byte) uint16 {
 // StatusErrFormat indicates invalid seed format
 var StatusErrFormat = &storageError{msg: "invalid seed format"}
 
+// StatusErrExtraByte indicates invalid seed format because of a wrong
+// extra byte, with the data before it valid
+var StatusErrExtraByte = &storageError{msg: "invalid seed format"}
+
 type storageError struct {
 	msg string
 }
@@ -49,7 +53,7 @@ func DataStore(d *Data, storage *[32]byte) {
 	pos += headerSize
 
 	// Features and birthday
-	store16(storage[pos:], uint16(d.Features)<<DateBits|uint16(d.Birthday))
+	store16(storage[pos:], PackMeta(d.Features, d.Birthday))
 	pos += 2
 
 	// Secret
@@ -64,24 +68,71 @@ func DataStore(d *Data, storage *[32]byte) {
 	store16(storage[pos:], storageFooter|d.Checksum)
 }
 
+// Storage layouts accepted by DataLoadLenient
+const (
+	// LayoutAnyExtraByte has the footer in place, with any extra byte
+	LayoutAnyExtraByte = iota
+	// LayoutNoExtraByte has the footer in the place of the extra byte,
+	// followed by a zero byte
+	LayoutNoExtraByte
+)
+
 // DataLoad deserializes seed data from storage format
 func DataLoad(storage *[32]byte, d *Data) error {
+	pos, err := dataLoadPrefix(storage, d)
+	if err != nil {
+		return err
+	}
+
+	// Check extra byte
+	if storage[pos] != extraByte {
+		return StatusErrExtraByte
+	}
+	pos++
+
+	return dataLoadFooter(storage, d, pos)
+}
+
+// DataLoadLenient deserializes seed data like DataLoad, but in one of the
+// layouts of implementations that do not write the extra byte
+func DataLoadLenient(storage *[32]byte, d *Data, layout int) error {
+	pos, err := dataLoadPrefix(storage, d)
+	if err != nil {
+		return err
+	}
+
+	switch layout {
+	case LayoutAnyExtraByte:
+		pos++
+	case LayoutNoExtraByte:
+		if storage[len(storage)-1] != 0 {
+			return StatusErrFormat
+		}
+	default:
+		return StatusErrFormat
+	}
+
+	return dataLoadFooter(storage, d, pos)
+}
+
+// dataLoadPrefix loads the header, features, birthday and secret, and
+// returns the position of the extra byte
+func dataLoadPrefix(storage *[32]byte, d *Data) (int, error) {
 	pos := 0
 
 	// Check header
 	if string(storage[pos:pos+headerSize]) != storageHeader {
-		return StatusErrFormat
+		return 0, StatusErrFormat
 	}
 	pos += headerSize
 
 	// Load features and birthday
-	v1 := load16(storage[pos:])
-	d.Birthday = uint16(v1 & DateMask)
-	v1 >>= DateBits
-	if v1 > FeatureMask {
-		return StatusErrFormat
+	features, birthday := UnpackMeta(load16(storage[pos:]))
+	if features > FeatureMask {
+		return 0, StatusErrFormat
 	}
-	d.Features = uint8(v1)
+	d.Features = features
+	d.Birthday = birthday
 	pos += 2
 
 	// Load secret
@@ -90,16 +141,15 @@ func DataLoad(storage *[32]byte, d *Data) error {
 	}
 	copy(d.Secret[:], storage[pos:pos+SecretSize])
 	if d.Secret[SecretSize-1]&^ClearMask != 0 {
-		return StatusErrFormat
+		return 0, StatusErrFormat
 	}
 	pos += SecretSize
 
-	// Check extra byte
-	if storage[pos] != extraByte {
-		return StatusErrFormat
-	}
-	pos++
+	return pos, nil
+}
 
+// dataLoadFooter checks the footer at pos and loads the checksum
+func dataLoadFooter(storage *[32]byte, d *Data, pos int) error {
 	// Check footer and load checksum
 	v2 := load16(storage[pos:])
 	d.Checksum = uint16(v2 & GfMask)
@@ -111,3 +161,60 @@ func DataLoad(storage *[32]byte, d *Data) error {
 	return nil
 }
 
+// CompactSize is the size of the compact serialization. It holds exactly
+// the 176 significant bits of the seed data.
+const CompactSize = 22
+
+// putBits stores the n low bits of v at bit offset pos, most significant
+// bit first, and returns the new offset
+func putBits(buf []byte, pos int, v uint32, n int) int {
+	for i := n - 1; i >= 0; i-- {
+		if v>>i&1 != 0 {
+			buf[pos/8] |= 0x80 >> (pos % 8)
+		}
+		pos++
+	}
+	return pos
+}
+
+// getBits loads n bits at bit offset pos, most significant bit first, and
+// returns them with the new offset
+func getBits(buf []byte, pos int, n int) (uint32, int) {
+	v := uint32(0)
+	for i := 0; i < n; i++ {
+		v = v<<1 | uint32(buf[pos/8]>>(7-pos%8)&1)
+		pos++
+	}
+	return v, pos
+}
+
+// DataStoreCompact serializes seed data without the constant header and
+// footer. The features and birthday (15 bits), checksum (11 bits) and
+// secret (150 bits) are packed without padding.
+func DataStoreCompact(d *Data, compact *[CompactSize]byte) {
+	*compact = [CompactSize]byte{}
+	pos := putBits(compact[:], 0, uint32(PackMeta(d.Features, d.Birthday)), FeatureBits+DateBits)
+	pos = putBits(compact[:], pos, uint32(d.Checksum), GfBits)
+	for i := 0; i < SecretSize-1; i++ {
+		pos = putBits(compact[:], pos, uint32(d.Secret[i]), 8)
+	}
+	putBits(compact[:], pos, uint32(d.Secret[SecretSize-1]), 8-clearBits)
+}
+
+// DataLoadCompact deserializes seed data stored by DataStoreCompact
+func DataLoadCompact(compact *[CompactSize]byte, d *Data) {
+	meta, pos := getBits(compact[:], 0, FeatureBits+DateBits)
+	d.Features, d.Birthday = UnpackMeta(uint16(meta))
+	checksum, pos := getBits(compact[:], pos, GfBits)
+	d.Checksum = uint16(checksum)
+	for i := range d.Secret {
+		d.Secret[i] = 0
+	}
+	for i := 0; i < SecretSize-1; i++ {
+		var v uint32
+		v, pos = getBits(compact[:], pos, 8)
+		d.Secret[i] = byte(v)
+	}
+	last, _ := getBits(compact[:], pos, 8-clearBits)
+	d.Secret[SecretSize-1] = byte(last)
+}
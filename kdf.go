@@ -0,0 +1,280 @@
+// Copyright (c) 2025-2026 complex (complex@ft.hn)
+// See LICENSE for licensing information
+
+package polyseed
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+)
+
+// kdfCheckInterval is the number of PBKDF2 iterations between checks for
+// cancellation
+const kdfCheckInterval = 1000
+
+// KDF is a key derivation function used for seed encryption and key
+// generation.
+//
+// Seeds encrypted or keys derived with a KDF other than PBKDF2KDF with the
+// default iteration count are not compatible with other polyseed
+// implementations: the same KDF and parameters must be used to decrypt the
+// seed or reproduce the keys.
+type KDF interface {
+	// Derive derives a key of keyLen bytes from a password and a salt
+	Derive(password, salt []byte, keyLen int) []byte
+}
+
+// PBKDF2KDF is PBKDF2 based on HMAC-SHA256, the KDF of the polyseed
+// specification. A zero iteration count uses the specification's 10000
+// iterations. A negative count is invalid: CryptWithKDF and DeriveContext
+// return ErrIterations for it, and Derive panics.
+type PBKDF2KDF struct {
+	Iterations int
+}
+
+// iterations returns the iteration count to use, or ErrIterations if it
+// is negative
+func (k PBKDF2KDF) iterations() (int, error) {
+	switch {
+	case k.Iterations < 0:
+		return 0, ErrIterations
+	case k.Iterations == 0:
+		return kdfNumIterations, nil
+	}
+	return k.Iterations, nil
+}
+
+// Derive implements KDF
+func (k PBKDF2KDF) Derive(password, salt []byte, keyLen int) []byte {
+	iterations, err := k.iterations()
+	if err != nil {
+		panic("polyseed: PBKDF2KDF: " + err.Error())
+	}
+	return pbkdf2SHA256(password, salt, iterations, keyLen)
+}
+
+// Argon2idKDF is Argon2id (RFC 9106), a memory-hard KDF that makes
+// guessing the password or the secret on specialized hardware more
+// expensive than PBKDF2. Zero fields use the second recommended option of
+// RFC 9106: 3 passes over 64 MiB with 4 lanes.
+//
+// Seeds encrypted and keys derived with Argon2idKDF are not compatible with
+// other polyseed implementations, and the same parameters must be used to
+// decrypt the seed or reproduce the keys.
+type Argon2idKDF struct {
+	// Time is the number of passes over the memory
+	Time uint32
+	// Memory is the memory size in KiB
+	Memory uint32
+	// Threads is the number of lanes, which changes the derived key
+	Threads uint8
+}
+
+// Derive implements KDF
+func (k Argon2idKDF) Derive(password, salt []byte, keyLen int) []byte {
+	time, memory, threads := k.Time, k.Memory, k.Threads
+	if time == 0 {
+		time = 3
+	}
+	if memory == 0 {
+		memory = 64 * 1024
+	}
+	if threads == 0 {
+		threads = 4
+	}
+	return argon2.IDKey(password, salt, time, memory, threads, uint32(keyLen))
+}
+
+// ContextKDF is a KDF that can be cancelled while it runs. deriveContext
+// uses it if the KDF implements it, and otherwise only checks for
+// cancellation before and after the derivation.
+type ContextKDF interface {
+	KDF
+
+	// DeriveContext is like Derive, but returns the context's error if
+	// the context is done before the key is derived
+	DeriveContext(ctx context.Context, password, salt []byte, keyLen int) ([]byte, error)
+}
+
+// DeriveContext implements ContextKDF. The context is checked every 1000
+// iterations.
+func (k PBKDF2KDF) DeriveContext(ctx context.Context, password, salt []byte, keyLen int) ([]byte, error) {
+	iterations, err := k.iterations()
+	if err != nil {
+		return nil, err
+	}
+	return pbkdf2SHA256Context(ctx, password, salt, iterations, keyLen)
+}
+
+// pbkdf2SHA256Context calculates PBKDF2 based on HMAC-SHA256 like
+// pbkdf2SHA256, checking the context for cancellation every
+// kdfCheckInterval iterations. It is only used where cancellation is
+// needed; pbkdf2SHA256 uses the library implementation.
+//
+// Returns ErrKeySize if keyLen is negative.
+func pbkdf2SHA256Context(ctx context.Context, password, salt []byte, iterations, keyLen int) ([]byte, error) {
+	if keyLen < 0 {
+		return nil, ErrKeySize
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	prf := hmac.New(sha256.New, password)
+	hashLen := prf.Size()
+	numBlocks := (keyLen + hashLen - 1) / hashLen
+
+	dk := make([]byte, 0, numBlocks*hashLen)
+	u := make([]byte, hashLen)
+	defer memzero(u)
+	var blockIndex [4]byte
+	for block := 1; block <= numBlocks; block++ {
+		// U_1 = PRF(password, salt || INT(block))
+		prf.Reset()
+		prf.Write(salt)
+		binary.BigEndian.PutUint32(blockIndex[:], uint32(block))
+		prf.Write(blockIndex[:])
+		dk = prf.Sum(dk)
+		t := dk[len(dk)-hashLen:]
+		copy(u, t)
+
+		// U_n = PRF(password, U_{n-1}), T = U_1 ^ ... ^ U_c
+		for n := 2; n <= iterations; n++ {
+			if n%kdfCheckInterval == 0 {
+				if err := ctx.Err(); err != nil {
+					memzero(dk)
+					return nil, err
+				}
+			}
+			prf.Reset()
+			prf.Write(u)
+			u = prf.Sum(u[:0])
+			for i := range u {
+				t[i] ^= u[i]
+			}
+		}
+	}
+	return dk[:keyLen], nil
+}
+
+// deriveContext derives a key with kdf, with cancellation if kdf is a
+// ContextKDF
+func deriveContext(ctx context.Context, kdf KDF, password, salt []byte, keyLen int) ([]byte, error) {
+	if ckdf, ok := kdf.(ContextKDF); ok {
+		return ckdf.DeriveContext(ctx, password, salt, keyLen)
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	key := kdf.Derive(password, salt, keyLen)
+	if err := ctx.Err(); err != nil {
+		memzero(key)
+		return nil, err
+	}
+	return key, nil
+}
+
+// defaultKDF is the KDF of the polyseed specification, used by Crypt and
+// Keygen. It is not exported, so that no caller can change what these
+// functions derive for the whole process; CryptWithKDF and KeygenWithKDF
+// take other KDFs explicitly.
+var defaultKDF = PBKDF2KDF{Iterations: kdfNumIterations}
+
+// CryptWithKDF is like Crypt, but derives the encryption mask with kdf.
+//
+// Returns ErrIterations if kdf is a PBKDF2KDF with a negative iteration
+// count.
+func (s *Seed) CryptWithKDF(password string, kdf KDF) error {
+	passNorm := utf8NFKD(password)
+	if strings.TrimSpace(passNorm) == "" {
+		return ErrEmptyPassword
+	}
+	if k, ok := kdf.(PBKDF2KDF); ok {
+		if _, err := k.iterations(); err != nil {
+			return err
+		}
+	}
+	s.crypt(passNorm, kdf)
+	return nil
+}
+
+// KeygenWithKDF is like Keygen, but derives the key with kdf. It panics if
+// kdf is a PBKDF2KDF with a negative iteration count.
+func (s *Seed) KeygenWithKDF(coin Coin, keySize int, kdf KDF) SecretBytes {
+	return s.keygen(coin, keySize, kdf, nil)
+}
+
+// keygen derives a key with kdf from the Keygen salt, which extend may
+// modify or extend for domain separation
+func (s *Seed) keygen(coin Coin, keySize int, kdf KDF, extend func(salt []byte) []byte) SecretBytes {
+	d := s.pooledData()
+	defer putData(d)
+
+	salt := keygenSalt(d, coin)
+	if extend != nil {
+		salt = extend(salt)
+	}
+
+	// Use full secret buffer (32 bytes)
+	return kdf.Derive(d.Secret[:], salt, keySize)
+}
+
+// KeygenContext is like Keygen, but stops deriving the key when ctx is
+// done, for example when the user leaves the screen that needs the key.
+//
+// Returns the key, nil and ctx.Err() if ctx is done first, or nil and
+// ErrKeySize if keySize is negative.
+func (s *Seed) KeygenContext(ctx context.Context, coin Coin, keySize int) (SecretBytes, error) {
+	d := s.pooledData()
+	defer putData(d)
+
+	salt := keygenSalt(d, coin)
+
+	key, err := deriveContext(ctx, defaultKDF, d.Secret[:], salt, keySize)
+	if err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// CryptContext is like Crypt, but stops deriving the encryption mask when
+// ctx is done.
+//
+// Returns ctx.Err() if ctx is done first, in which case the seed is not
+// changed, and ErrEmptyPassword if the password is empty.
+func (s *Seed) CryptContext(ctx context.Context, password string) error {
+	passNorm := utf8NFKD(password)
+	if strings.TrimSpace(passNorm) == "" {
+		return ErrEmptyPassword
+	}
+	mask, err := deriveContext(ctx, defaultKDF, []byte(passNorm), cryptSalt(), 32)
+	if err != nil {
+		return err
+	}
+	s.applyMask(mask)
+	memzero(mask)
+	return nil
+}
+
+// CryptOptions are the parameters for CryptWithOptions
+type CryptOptions struct {
+	// Iterations is the PBKDF2 iteration count. Zero means the default of
+	// 10000 iterations, and a negative count is rejected with
+	// ErrIterations. The same count must be used to decrypt the seed.
+	Iterations int
+}
+
+// CryptWithOptions is like Crypt, but derives the encryption mask with the
+// PBKDF2 parameters in opts. A seed encrypted with a non-default iteration
+// count can only be decrypted with the same count, and not by other
+// polyseed implementations.
+//
+// Returns ErrIterations if the iteration count is negative.
+func (s *Seed) CryptWithOptions(password string, opts CryptOptions) error {
+	return s.CryptWithKDF(password, PBKDF2KDF{Iterations: opts.Iterations})
+}
@@ -0,0 +1,195 @@
+// Copyright (c) 2025-2026 complex (complex@ft.hn)
+// See LICENSE for licensing information
+
+package polyseed
+
+import (
+	"crypto/sha256"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+
+	"golang.org/x/crypto/hkdf"
+)
+
+// keyCacheKey identifies a key cached by KeygenCached
+type keyCacheKey struct {
+	coin    Coin
+	keySize int
+}
+
+// keyCacheMu guards the key caches of all seeds
+var keyCacheMu sync.Mutex
+
+// SecretBytes is secret key material returned by Keygen and its variants.
+// It is a []byte, so it can be used wherever a []byte is expected, and
+// adds Zero to make wiping the key after use easy:
+//
+//	key := seed.Keygen(polyseed.CoinMonero, 32)
+//	defer key.Zero()
+type SecretBytes []byte
+
+// Zero securely erases the key material
+func (b SecretBytes) Zero() {
+	memzero(b)
+}
+
+// Bytes returns the key material as a plain []byte sharing the same memory
+func (b SecretBytes) Bytes() []byte {
+	return b
+}
+
+// parsePath parses a derivation path of the form "0/1/2"
+func parsePath(path string) ([]uint32, error) {
+	if path == "" {
+		return nil, nil
+	}
+	var indices []uint32
+	for _, seg := range strings.Split(path, "/") {
+		idx, err := strconv.ParseUint(seg, 10, 32)
+		if err != nil {
+			return nil, ErrPath
+		}
+		indices = append(indices, uint32(idx))
+	}
+	return indices, nil
+}
+
+// KeygenPath derives a secret key for a derivation path.
+//
+// path is a list of non-negative 32-bit integers separated by slashes, such
+// as "0/1/2". Each segment is appended to the Keygen salt as a 32-bit
+// little-endian value, in order, and the key is derived with a single
+// PBKDF2 run over the extended salt. An empty path yields the same key as
+// Keygen.
+//
+// Returns the key and an error if the path is malformed.
+func (s *Seed) KeygenPath(coin Coin, path string, keySize int) (SecretBytes, error) {
+	indices, err := parsePath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	key := s.keygen(coin, keySize, defaultKDF, func(salt []byte) []byte {
+		// Domain separate by path (32-bit per segment)
+		for _, idx := range indices {
+			var seg [4]byte
+			store32(seg[:], idx)
+			salt = append(salt, seg[:]...)
+		}
+		return salt
+	})
+
+	return key, nil
+}
+
+// KeygenAccount derives a secret key for an account index.
+//
+// The account is stored in the last 4 bytes of the Keygen salt, which are
+// zero otherwise, as a 32-bit little-endian value. Account 0 therefore
+// yields the same key as Keygen.
+func (s *Seed) KeygenAccount(coin Coin, account uint32, keySize int) SecretBytes {
+	return s.keygen(coin, keySize, defaultKDF, func(salt []byte) []byte {
+		// Domain separate by account (32-bit)
+		store32(salt[28:], account)
+		return salt
+	})
+}
+
+// multiKeySize is the size of the PBKDF2 output that KeygenMulti expands
+const multiKeySize = 32
+
+// KeygenMulti derives several secret keys with a single PBKDF2 run.
+//
+// A 32-byte key is derived like Keygen and used as the pseudorandom key of
+// HKDF-SHA256 (RFC 5869), which expands it into each requested key. The
+// info of key i is "POLYSEED multi key" followed by i as a 32-bit
+// little-endian value, so keys at different positions are independent even
+// if they have the same size. The keys do not equal those of Keygen, and
+// other polyseed implementations do not provide them.
+//
+// Returns the keys, or ErrKeySize if a size is negative or larger than
+// the 8160 bytes HKDF-SHA256 can expand.
+func (s *Seed) KeygenMulti(coin Coin, sizes ...int) ([]SecretBytes, error) {
+	for _, size := range sizes {
+		if size < 0 || size > 255*sha256.Size {
+			return nil, ErrKeySize
+		}
+	}
+
+	keys := make([]SecretBytes, len(sizes))
+	if len(sizes) == 0 {
+		return keys, nil
+	}
+
+	prk := s.Keygen(coin, multiKeySize)
+	defer prk.Zero()
+
+	info := make([]byte, len("POLYSEED multi key")+4)
+	copy(info, "POLYSEED multi key")
+	for i, size := range sizes {
+		// Domain separate by position (32-bit)
+		store32(info[len(info)-4:], uint32(i))
+
+		keys[i] = make(SecretBytes, size)
+		if _, err := io.ReadFull(hkdf.Expand(sha256.New, prk, info), keys[i]); err != nil {
+			// Unreachable, the size was checked
+			for _, key := range keys {
+				memzero(key)
+			}
+			return nil, err
+		}
+	}
+	return keys, nil
+}
+
+// KeygenCached derives a secret key like Keygen, but keeps the key in a
+// cache of the seed so that later calls with the same coin and key size
+// return it without running PBKDF2 again. It suits wallets that derive the
+// same key repeatedly. Each call returns a new copy of the key, which the
+// caller owns.
+//
+// The cache keeps key material in memory for the lifetime of the seed, in
+// addition to the secret itself, and uses keySize bytes per distinct
+// parameter pair. It is wiped by Free and whenever the seed changes, for
+// example by Crypt or SetFeature. Clones and snapshots start with an empty
+// cache. Use Keygen if derived keys should not stay in memory.
+func (s *Seed) KeygenCached(coin Coin, keySize int) SecretBytes {
+	k := keyCacheKey{coin: coin, keySize: keySize}
+
+	keyCacheMu.Lock()
+	if key, ok := s.keys[k]; ok {
+		out := append(SecretBytes(nil), key...)
+		keyCacheMu.Unlock()
+		return out
+	}
+	keyCacheMu.Unlock()
+
+	key := s.Keygen(coin, keySize)
+
+	keyCacheMu.Lock()
+	defer keyCacheMu.Unlock()
+	if cached, ok := s.keys[k]; ok {
+		// Derived concurrently by another call
+		memzero(key)
+		key = cached
+	} else {
+		if s.keys == nil {
+			s.keys = make(map[keyCacheKey][]byte)
+		}
+		s.keys[k] = key
+	}
+	return append(SecretBytes(nil), key...)
+}
+
+// wipeKeys erases and drops the keys cached by KeygenCached
+func (s *Seed) wipeKeys() {
+	keyCacheMu.Lock()
+	for k, key := range s.keys {
+		memzero(key)
+		delete(s.keys, k)
+	}
+	s.keys = nil
+	keyCacheMu.Unlock()
+}
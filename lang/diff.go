@@ -0,0 +1,71 @@
+// Copyright (c) 2025-2026 complex (complex@ft.hn)
+// See LICENSE for licensing information
+
+package lang
+
+// WordDiff describes a wordlist position where two languages differ
+type WordDiff struct {
+	// Index is the position in the wordlists
+	Index int
+
+	// A and B are the words of the two languages at Index
+	A, B string
+
+	// SharedPrefix is the number of leading characters the words have in
+	// common, with accents removed if either language has accents
+	SharedPrefix int
+
+	// Equivalent is true if the first language accepts the word of the
+	// second one in its place, for example because they only differ in
+	// accents. Phrases written with B then still decode with the first
+	// language.
+	Equivalent bool
+}
+
+// DiffWordlists compares the wordlists of two languages position by
+// position and returns the positions where the words differ, in order.
+// It helps to review a wordlist derived from another one, such as a
+// regional variant, for unintended changes.
+func DiffWordlists(a, b *Language) []WordDiff {
+	fold := a.HasAccents || b.HasAccents
+
+	var diffs []WordDiff
+	for i := range a.Words {
+		wa, wb := a.Words[i], b.Words[i]
+		if wa == wb {
+			continue
+		}
+		fa, fb := wa, wb
+		if fold {
+			fa, fb = removeAccents(wa), removeAccents(wb)
+		}
+		diffs = append(diffs, WordDiff{
+			Index:        i,
+			A:            wa,
+			B:            wb,
+			SharedPrefix: sharedPrefix(fa, fb),
+			Equivalent:   a.accepts(i, wb),
+		})
+	}
+	return diffs
+}
+
+// accepts reports whether word matches the word at index i under the
+// matching rules of the language
+func (l *Language) accepts(i int, word string) bool {
+	if l.HasAccents {
+		word = removeAccents(word)
+	}
+	return l.compare(word, l.foldedWord(i)) == 0
+}
+
+// sharedPrefix returns the number of leading runes two strings have in
+// common
+func sharedPrefix(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	n := 0
+	for n < len(ra) && n < len(rb) && ra[n] == rb[n] {
+		n++
+	}
+	return n
+}
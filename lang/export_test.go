@@ -0,0 +1,21 @@
+// Copyright (c) 2025-2026 complex (complex@ft.hn)
+// See LICENSE for licensing information
+
+package lang
+
+// UnregisterLanguage removes a language added by RegisterLanguage, so that
+// tests can restore the registry
+func UnregisterLanguage(l *Language) {
+	languagesMu.Lock()
+	defer languagesMu.Unlock()
+
+	// Readers may hold the old slice, so it is copied
+	var kept []*Language
+	for _, lang := range languages {
+		if lang != l {
+			kept = append(kept, lang)
+		}
+	}
+	languages = kept
+	delete(wordIndexes, l)
+}
@@ -5,8 +5,12 @@ package lang
 
 import (
 	"errors"
+	"fmt"
 	"sort"
 	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
 
 	"golang.org/x/text/unicode/norm"
 )
@@ -16,9 +20,12 @@ var (
 	ErrLang = errors.New("unknown language or unsupported words")
 	// ErrMultLang indicates phrase matches more than one language
 	ErrMultLang = errors.New("phrase matches more than one language")
+	// ErrWordlist indicates a malformed language wordlist
+	ErrWordlist = errors.New("invalid wordlist")
 )
 
 const (
+	// numCharsPrefix is the default prefix length of a language
 	numCharsPrefix = 4
 	// LangSize is the number of words in each language wordlist
 	LangSize = 2048
@@ -30,30 +37,232 @@ const (
 type Language struct {
 	Name       string
 	NameEn     string
+	// Code is the BCP 47 language tag of the wordlist, such as "en" or
+	// "zh-Hans". It may be empty for custom languages.
+	Code       string
 	Separator  string
 	IsSorted   bool
 	HasPrefix  bool
 	HasAccents bool
 	Compose    bool
 	Words      [LangSize]string
+
+	// NoSpaceSplit indicates that phrases may be written without separators
+	// between the words, which requires HasPrefix to be false
+	NoSpaceSplit bool
+
+	// PrefixLength is the number of leading characters that identify a word
+	// if HasPrefix is set. Zero means 4.
+	PrefixLength int
 }
 
 var (
+	// languagesMu guards languages and wordIndexes
+	languagesMu sync.RWMutex
+
 	// languages contains all supported languages
 	languages []*Language
+
+	// wordIndexes holds the lookup data of every registered language
+	wordIndexes = map[*Language]*wordIndex{}
 )
 
+// registered returns the registered languages. RegisterLanguage only
+// appends, so the returned slice stays valid and must not be modified.
+func registered() []*Language {
+	languagesMu.RLock()
+	defer languagesMu.RUnlock()
+	return languages
+}
+
+// wordIndex holds precomputed lookup data for a language
+type wordIndex struct {
+	// keys maps the matching key of each word to its index
+	keys map[string]uint16
+	// folded holds the words with accents removed if the language has
+	// accents, or the words themselves otherwise
+	folded [LangSize]string
+}
+
 // GetNumLangs returns the number of supported languages
 func GetNumLangs() int {
-	return len(languages)
+	return len(registered())
 }
 
 // GetLang returns a language by its index
 func GetLang(i int) *Language {
-	if i < 0 || i >= len(languages) {
+	langs := registered()
+	if i < 0 || i >= len(langs) {
 		return nil
 	}
-	return languages[i]
+	return langs[i]
+}
+
+// GetLangByName returns a language by its English name, ignoring case.
+// Returns nil if no language matches.
+func GetLangByName(nameEn string) *Language {
+	return langByName(registered(), nameEn)
+}
+
+// langByName returns the language of langs with an English name
+func langByName(langs []*Language, nameEn string) *Language {
+	for _, lang := range langs {
+		if strings.EqualFold(lang.NameEn, nameEn) {
+			return lang
+		}
+	}
+	return nil
+}
+
+// GetLangByNativeName returns a language by its native name. Both names
+// are compared in NFC form. Returns nil if no language matches.
+func GetLangByNativeName(name string) *Language {
+	nameNorm := norm.NFC.String(name)
+	for _, lang := range registered() {
+		if norm.NFC.String(lang.Name) == nameNorm {
+			return lang
+		}
+	}
+	return nil
+}
+
+// GetLangByCode returns a language by its language code, ignoring case.
+// Underscores are accepted in place of hyphens, so POSIX locale names such
+// as "pt_BR" work too. If no code matches exactly, the last subtag is
+// dropped until one does, so "en-US" finds English and "zh-Hant-TW" finds
+// Chinese (Traditional). Returns nil if no language matches.
+func GetLangByCode(code string) *Language {
+	code = strings.ReplaceAll(code, "_", "-")
+	langs := registered()
+	for code != "" {
+		if lang := langByCode(langs, code); lang != nil {
+			return lang
+		}
+		i := strings.LastIndexByte(code, '-')
+		if i < 0 {
+			break
+		}
+		code = code[:i]
+	}
+	return nil
+}
+
+// langByCode returns the language of langs with a language code, ignoring
+// case
+func langByCode(langs []*Language, code string) *Language {
+	for _, lang := range langs {
+		if strings.EqualFold(lang.Code, code) {
+			return lang
+		}
+	}
+	return nil
+}
+
+// RegisterLanguage adds a custom language wordlist. The wordlist must have
+// 2048 non-empty entries that are unique under the language's matching
+// rules. It is safe to call concurrently with decoding, but is best called
+// during program initialization, since phrases decoded before a language
+// is registered may be detected differently. The language must not be
+// modified after it is registered.
+//
+// Returns an error wrapping ErrWordlist if the wordlist is malformed or a
+// language with the same English name or code is already registered.
+func RegisterLanguage(l *Language) error {
+	if l == nil {
+		return fmt.Errorf("%w: nil language", ErrWordlist)
+	}
+	if err := l.Verify(); err != nil {
+		return err
+	}
+	index := l.buildIndex()
+
+	languagesMu.Lock()
+	defer languagesMu.Unlock()
+	if langByName(languages, l.NameEn) != nil {
+		return fmt.Errorf("%w: language %q is already registered", ErrWordlist, l.NameEn)
+	}
+	if l.Code != "" && langByCode(languages, l.Code) != nil {
+		return fmt.Errorf("%w: language code %q is already registered", ErrWordlist, l.Code)
+	}
+	languages = append(languages, l)
+	wordIndexes[l] = index
+	return nil
+}
+
+// index returns the lookup data of the language, or nil if it is not
+// registered
+func (l *Language) index() *wordIndex {
+	languagesMu.RLock()
+	defer languagesMu.RUnlock()
+	return wordIndexes[l]
+}
+
+// wordKey returns the part of a word that is significant for matching
+func (l *Language) wordKey(word string) string {
+	if l.HasAccents {
+		word = removeAccents(word)
+	}
+	return l.foldedKey(word)
+}
+
+// foldedKey is like wordKey for a word that has already been folded
+func (l *Language) foldedKey(word string) string {
+	if l.HasPrefix {
+		runes := []rune(word)
+		if n := l.prefixLength(); len(runes) > n {
+			word = string(runes[:n])
+		}
+	}
+	return word
+}
+
+// Verify checks the integrity of the wordlist: every word is present, in
+// NFKD form and uniquely identifiable under the matching rules of the
+// language, and the words are in order if IsSorted is set.
+//
+// Returns an error wrapping ErrWordlist that describes the first problem.
+func (l *Language) Verify() error {
+	if l.NoSpaceSplit && l.HasPrefix {
+		return fmt.Errorf("%w: NoSpaceSplit requires exact matching", ErrWordlist)
+	}
+	if l.PrefixLength < 0 {
+		return fmt.Errorf("%w: negative prefix length %d", ErrWordlist, l.PrefixLength)
+	}
+	seen := make(map[string]int, LangSize)
+	for i, word := range l.Words {
+		if word == "" {
+			return fmt.Errorf("%w: empty word at index %d", ErrWordlist, i)
+		}
+		if norm.NFKD.String(word) != word {
+			return fmt.Errorf("%w: word %q (%d) is not in NFKD form", ErrWordlist, word, i)
+		}
+		key := l.wordKey(word)
+		if j, ok := seen[key]; ok {
+			return fmt.Errorf("%w: words %q (%d) and %q (%d) are indistinguishable",
+				ErrWordlist, l.Words[j], j, word, i)
+		}
+		seen[key] = i
+		if l.IsSorted && i > 0 && l.foldedWord(i-1) >= l.foldedWord(i) {
+			return fmt.Errorf("%w: words %q (%d) and %q (%d) are out of order",
+				ErrWordlist, l.Words[i-1], i-1, word, i)
+		}
+	}
+	return nil
+}
+
+// WordList returns a copy of the words of the language, in wordlist order
+func (l *Language) WordList() []string {
+	words := make([]string, LangSize)
+	copy(words, l.Words[:])
+	return words
+}
+
+// Word returns word i of the language. ok is false if i is out of range.
+func (l *Language) Word(i int) (word string, ok bool) {
+	if i < 0 || i >= LangSize {
+		return "", false
+	}
+	return l.Words[i], true
 }
 
 // GetLangName returns the native name of a language
@@ -66,155 +275,261 @@ func (l *Language) GetLangNameEn() string {
 	return l.NameEn
 }
 
+// prefixLength returns the number of characters that identify a word
+func (l *Language) prefixLength() int {
+	if l.PrefixLength == 0 {
+		return numCharsPrefix
+	}
+	return l.PrefixLength
+}
+
 // compareStr compares two strings
 func compareStr(key, elm string) int {
 	return strings.Compare(key, elm)
 }
 
-// comparePrefix compares strings using prefix matching (first 4 chars for Latin)
-func comparePrefix(key, elm string) int {
+// comparePrefix compares strings using prefix matching. The key matches a
+// word if it is equal to it, or if it has at least prefixLen runes and
+// the word starts with it. The order is the same as for compareStr.
+func comparePrefix(key, elm string, prefixLen int) int {
 	keyRunes := []rune(key)
 	elmRunes := []rune(elm)
-	
+
 	for i := 1; ; i++ {
 		if len(keyRunes) == 0 {
 			break
 		}
-		if len(elmRunes) == 0 {
+		// Stop at the last rune of a key that is long enough
+		if i >= prefixLen && len(keyRunes) == 1 {
 			break
 		}
-		if keyRunes[0] != elmRunes[0] {
+		if len(elmRunes) == 0 || keyRunes[0] != elmRunes[0] {
 			break
 		}
 		keyRunes = keyRunes[1:]
 		elmRunes = elmRunes[1:]
-		// Stop after matching prefix (4 chars) if only one char remains
-		if i >= numCharsPrefix && len(keyRunes) == 1 {
-			break
-		}
-	}
-	
-	if len(keyRunes) == 0 && len(elmRunes) == 0 {
-		return 0
 	}
-	if len(keyRunes) == 0 {
-		return -1
-	}
-	if len(elmRunes) == 0 {
-		return 1
+
+	// Compare the runes where matching stopped, the end of a string
+	// sorts first
+	var k, e rune
+	if len(keyRunes) > 0 {
+		k = keyRunes[0]
 	}
-	// If both have remaining characters, check if they match
-	if len(keyRunes) > 0 && len(elmRunes) > 0 && keyRunes[0] == elmRunes[0] {
-		// Check if both are now empty after this match
-		if len(keyRunes) == 1 && len(elmRunes) == 1 {
-			return 0
-		}
+	if len(elmRunes) > 0 {
+		e = elmRunes[0]
 	}
-	if keyRunes[0] < elmRunes[0] {
+	switch {
+	case k < e:
 		return -1
+	case k > e:
+		return 1
 	}
-	return 1
+	return 0
 }
 
-// removeAccents removes non-ASCII characters (simplified version)
+// removeAccents removes combining marks from the canonical decomposition
+// of a string and recomposes the remaining characters
 func removeAccents(s string) string {
 	var result strings.Builder
-	for _, r := range s {
-		if r < 128 {
+	for _, r := range norm.NFD.String(s) {
+		if !unicode.Is(unicode.Mn, r) {
 			result.WriteRune(r)
 		}
 	}
-	return result.String()
-}
-
-// compareStrNoAccent compares strings ignoring accents
-func compareStrNoAccent(key, elm string) int {
-	keyClean := removeAccents(key)
-	elmClean := removeAccents(elm)
-	return strings.Compare(keyClean, elmClean)
+	return norm.NFC.String(result.String())
 }
 
-// comparePrefixNoAccent compares strings using prefix matching, ignoring accents
-func comparePrefixNoAccent(key, elm string) int {
-	keyClean := removeAccents(key)
-	elmClean := removeAccents(elm)
-	return comparePrefix(keyClean, elmClean)
+// compare compares a word to an entry of the wordlist with the matching
+// rules of the language. Accents must be removed from both words beforehand
+// if the language has accents.
+func (l *Language) compare(key, elm string) int {
+	if l.HasPrefix {
+		return comparePrefix(key, elm, l.prefixLength())
+	}
+	return compareStr(key, elm)
 }
 
-// langSearch searches for a word in a language wordlist
-func langSearch(lang *Language, word string, usePrefix, useNoAccent bool) int {
-	var cmp func(string, string) int
-	
-	if usePrefix {
-		if useNoAccent {
-			cmp = comparePrefixNoAccent
-		} else {
-			cmp = comparePrefix
-		}
-	} else {
-		if useNoAccent {
-			cmp = compareStrNoAccent
-		} else {
-			cmp = compareStr
-		}
+// langSearch searches for a word in a language wordlist. Accents are
+// ignored for languages with HasAccents.
+func langSearch(lang *Language, word string) int {
+	cmp := lang.compare
+	if lang.HasAccents {
+		word = removeAccents(word)
 	}
-	
+
 	if lang.IsSorted {
 		// Binary search for sorted wordlists
 		idx := sort.Search(LangSize, func(i int) bool {
-			return cmp(word, lang.Words[i]) <= 0
+			return cmp(word, lang.foldedWord(i)) <= 0
 		})
-		if idx < LangSize && cmp(word, lang.Words[idx]) == 0 {
+		if idx < LangSize && cmp(word, lang.foldedWord(idx)) == 0 {
 			return idx
 		}
 		return -1
 	}
-	
+
 	// Linear search for unsorted wordlists
 	for i := 0; i < LangSize; i++ {
-		if cmp(word, lang.Words[i]) == 0 {
+		if cmp(word, lang.foldedWord(i)) == 0 {
 			return i
 		}
 	}
 	return -1
 }
 
+// buildIndex precomputes the lookup data of the language. The keys are nil
+// if two words share a key.
+func (l *Language) buildIndex() *wordIndex {
+	index := &wordIndex{keys: make(map[string]uint16, LangSize)}
+	for i, word := range l.Words {
+		if l.HasAccents {
+			word = removeAccents(word)
+		}
+		index.folded[i] = word
+		key := l.foldedKey(word)
+		if _, ok := index.keys[key]; ok {
+			index.keys = nil
+		} else if index.keys != nil {
+			index.keys[key] = uint16(i)
+		}
+	}
+	return index
+}
+
+// foldedWord returns word i with accents removed if the language has
+// accents
+func (l *Language) foldedWord(i int) string {
+	if index := l.index(); index != nil {
+		return index.folded[i]
+	}
+	if l.HasAccents {
+		return removeAccents(l.Words[i])
+	}
+	return l.Words[i]
+}
+
 // FindWord finds a word in a language wordlist
 func (l *Language) FindWord(word string) int {
-	return langSearch(l, word, l.HasPrefix, l.HasAccents)
+	index := l.index()
+	if index == nil || index.keys == nil {
+		return langSearch(l, word)
+	}
+
+	// The index yields the only candidate, compare confirms it
+	if l.HasAccents {
+		word = removeAccents(word)
+	}
+	idx, ok := index.keys[l.foldedKey(word)]
+	if !ok || l.compare(word, index.folded[idx]) != 0 {
+		return -1
+	}
+	return int(idx)
+}
+
+// Suggest returns up to limit words that start with prefix, in wordlist
+// order. Accents are ignored for languages with HasAccents. If limit is zero
+// or negative, all matching words are returned.
+func (l *Language) Suggest(prefix string, limit int) []string {
+	prefix = utf8NFKDLazy(prefix)
+	if l.HasAccents {
+		prefix = removeAccents(prefix)
+	}
+
+	var words []string
+	for i, word := range l.Words {
+		if strings.HasPrefix(l.foldedWord(i), prefix) {
+			words = append(words, word)
+			if len(words) == limit {
+				break
+			}
+		}
+	}
+	return words
+}
+
+// levenshtein returns the edit distance between two strings
+func levenshtein(a, b []rune) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
+
+// Distance returns the edit distance between two words, counted in runes.
+// Accents are ignored for languages with HasAccents.
+func (l *Language) Distance(a, b string) int {
+	a, b = utf8NFKDLazy(a), utf8NFKDLazy(b)
+	if l.HasAccents {
+		a, b = removeAccents(a), removeAccents(b)
+	}
+	return levenshtein([]rune(a), []rune(b))
+}
+
+// FindClosest finds the word nearest to word by edit distance. Accents are
+// ignored for languages with HasAccents. Ties are resolved in favor of the
+// word that comes first in the wordlist.
+//
+// Returns the index and the word, and false if no word is within maxDistance.
+func (l *Language) FindClosest(word string, maxDistance int) (int, string, bool) {
+	word = utf8NFKDLazy(word)
+	if l.HasAccents {
+		word = removeAccents(word)
+	}
+	key := []rune(word)
+
+	best := -1
+	bestDist := maxDistance + 1
+	for i := range l.Words {
+		if dist := levenshtein(key, []rune(l.foldedWord(i))); dist < bestDist {
+			best = i
+			bestDist = dist
+			if dist == 0 {
+				break
+			}
+		}
+	}
+
+	if best < 0 {
+		return -1, "", false
+	}
+	return best, l.Words[best], true
 }
 
 // PhraseDecode decodes a phrase into word indices, auto-detecting the language
 func PhraseDecode(phrase []string) ([]uint16, *Language, error) {
 	var foundLang *Language
 	var foundIndices []uint16
-	
-	for _, lang := range languages {
-		indices := make([]uint16, NumWords)
-		success := true
-		
-		for i, word := range phrase {
-			idx := lang.FindWord(word)
-			if idx < 0 {
-				success = false
-				break
-			}
-			indices[i] = uint16(idx)
+
+	for _, lang := range registered() {
+		indices, err := PhraseDecodeExplicit(phrase, lang)
+		if err != nil {
+			continue
 		}
-		
-		if success {
-			if foundLang != nil {
-				return nil, nil, ErrMultLang
-			}
-			foundLang = lang
-			foundIndices = indices
+		if foundLang != nil {
+			return nil, nil, ErrMultLang
 		}
+		foundLang = lang
+		foundIndices = indices
 	}
-	
+
 	if foundLang == nil {
 		return nil, nil, ErrLang
 	}
-	
+
 	return foundIndices, foundLang, nil
 }
 
@@ -233,6 +548,99 @@ func PhraseDecodeExplicit(phrase []string, lang *Language) ([]uint16, error) {
 	return indices, nil
 }
 
+// SegmentPhrase splits a phrase written without separators into NumWords
+// words of the language. Only languages with NoSpaceSplit can be written
+// this way. Returns nil if the phrase cannot be segmented.
+func SegmentPhrase(str string, lang *Language) []string {
+	return SegmentParts([]string{str}, lang)
+}
+
+// SegmentParts splits a phrase whose words are only partly separated, such
+// as "弧悄 曼居", into NumWords words of the language. Each part holds one or
+// more whole words; words never span two parts. Only languages with
+// NoSpaceSplit can be written this way. Returns nil if the parts cannot be
+// segmented.
+func SegmentParts(parts []string, lang *Language) []string {
+	if !lang.NoSpaceSplit || len(parts) == 0 || len(parts) > NumWords {
+		return nil
+	}
+
+	maxLen := 0
+	for _, word := range lang.Words {
+		if n := utf8.RuneCountInString(word); n > maxLen {
+			maxLen = n
+		}
+	}
+
+	// end holds the end of the part containing each position
+	var runes []rune
+	var end []int
+	for _, part := range parts {
+		r := []rune(part)
+		for range r {
+			end = append(end, len(runes)+len(r))
+		}
+		runes = append(runes, r...)
+	}
+	failed := make(map[[2]int]bool)
+
+	var segment func(pos int, words []string) []string
+	segment = func(pos int, words []string) []string {
+		if pos == len(runes) {
+			if len(words) == NumWords {
+				return words
+			}
+			return nil
+		}
+		if len(words) == NumWords || failed[[2]int{pos, len(words)}] {
+			return nil
+		}
+		for n := 1; n <= maxLen && pos+n <= end[pos]; n++ {
+			word := string(runes[pos : pos+n])
+			if lang.FindWord(word) < 0 {
+				continue
+			}
+			if result := segment(pos+n, append(words, word)); result != nil {
+				return result
+			}
+		}
+		failed[[2]int{pos, len(words)}] = true
+		return nil
+	}
+
+	return segment(0, make([]string, 0, NumWords))
+}
+
+// PhraseDecodeJoined decodes a phrase written without separators, or with
+// only some of them, into word indices, auto-detecting the language. The
+// parts are segmented with SegmentParts.
+func PhraseDecodeJoined(parts ...string) ([]uint16, *Language, error) {
+	var foundLang *Language
+	var foundIndices []uint16
+
+	for _, lang := range registered() {
+		words := SegmentParts(parts, lang)
+		if words == nil {
+			continue
+		}
+		indices, err := PhraseDecodeExplicit(words, lang)
+		if err != nil {
+			continue
+		}
+		if foundLang != nil {
+			return nil, nil, ErrMultLang
+		}
+		foundLang = lang
+		foundIndices = indices
+	}
+
+	if foundLang == nil {
+		return nil, nil, ErrLang
+	}
+
+	return foundIndices, foundLang, nil
+}
+
 // utf8NFKDLazy only normalizes strings that contain non-ASCII characters
 func utf8NFKDLazy(str string) string {
 	// Check if string contains non-ASCII characters
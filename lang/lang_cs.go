@@ -7,6 +7,7 @@ package lang
 var LangCs = Language{
 	Name:       "čeština",
 	NameEn:     "Czech",
+	Code:       "cs",
 	Separator:  " ",
 	IsSorted:   true,
 	HasPrefix:  true,
@@ -7,6 +7,7 @@ package lang
 var LangEn = Language{
 	Name:       "English",
 	NameEn:     "English",
+	Code:       "en",
 	Separator:  " ",
 	IsSorted:   true,
 	HasPrefix:  true,
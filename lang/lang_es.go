@@ -7,6 +7,7 @@ package lang
 var LangEs = Language{
 	Name:       "español",
 	NameEn:     "Spanish",
+	Code:       "es",
 	Separator:  " ",
 	IsSorted:   true,
 	HasPrefix:  true,
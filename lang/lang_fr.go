@@ -7,6 +7,7 @@ package lang
 var LangFr = Language{
 	Name:       "français",
 	NameEn:     "French",
+	Code:       "fr",
 	Separator:  " ",
 	IsSorted:   true,
 	HasPrefix:  true,
@@ -20,5 +20,9 @@ func init() {
 		&LangZhS, // Chinese (Simplified)
 		&LangZhT, // Chinese (Traditional)
 	}
+
+	for _, lang := range languages {
+		wordIndexes[lang] = lang.buildIndex()
+	}
 }
 
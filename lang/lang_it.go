@@ -7,6 +7,7 @@ package lang
 var LangIt = Language{
 	Name:       "italiano",
 	NameEn:     "Italian",
+	Code:       "it",
 	Separator:  " ",
 	IsSorted:   true,
 	HasPrefix:  true,
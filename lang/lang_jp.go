@@ -7,6 +7,7 @@ package lang
 var LangJp = Language{
 	Name:       "日本語",
 	NameEn:     "Japanese",
+	Code:       "ja",
 	Separator:  "　",
 	IsSorted:   true,
 	HasPrefix:  false,
@@ -270,4 +271,5 @@ var LangJp = Language{
 		"ろてん", "ろめん", "ろれつ", "ろんぎ", "ろんぱ", "ろんぶん", "ろんり", "わかす",
 		"わかめ", "わかやま", "わかれる", "わしつ", "わじまし", "わすれもの", "わらう", "われる",
 	},
+	NoSpaceSplit: true,
 }
\ No newline at end of file
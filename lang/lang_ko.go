@@ -7,6 +7,7 @@ package lang
 var LangKo = Language{
 	Name:       "한국어",
 	NameEn:     "Korean",
+	Code:       "ko",
 	Separator:  " ",
 	IsSorted:   true,
 	HasPrefix:  false,
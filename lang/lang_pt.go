@@ -7,6 +7,7 @@ package lang
 var LangPt = Language{
 	Name:       "português",
 	NameEn:     "Portuguese",
+	Code:       "pt",
 	Separator:  " ",
 	IsSorted:   true,
 	HasPrefix:  true,
@@ -0,0 +1,225 @@
+// Copyright (c) 2025-2026 complex (complex@ft.hn)
+// See LICENSE for licensing information
+
+package lang_test
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	polyseed "github.com/complex-gh/polyseed_go"
+	"github.com/complex-gh/polyseed_go/lang"
+)
+
+// Tests that register languages live in this package, where
+// UnregisterLanguage can remove them again
+
+// phraseEn is the English phrase of the seed of the C tests
+const phraseEn = "raven tail swear infant grief assist regular lamp " +
+	"duck valid someone little harsh puppy airport language"
+
+// register registers a language for the duration of a test
+func register(t *testing.T, l *lang.Language) {
+	t.Helper()
+	if err := lang.RegisterLanguage(l); err != nil {
+		t.Fatalf("RegisterLanguage(%s) failed: %v", l.NameEn, err)
+	}
+	t.Cleanup(func() { lang.UnregisterLanguage(l) })
+}
+
+// decodeEn decodes phraseEn
+func decodeEn(t *testing.T) *polyseed.Seed {
+	t.Helper()
+	seed, _, err := polyseed.Decode(phraseEn, polyseed.CoinMonero)
+	if err != nil {
+		t.Fatalf("Failed to decode phrase: %v", err)
+	}
+	t.Cleanup(seed.Free)
+	return seed
+}
+
+func TestRegisterLanguage(t *testing.T) {
+	langEn := lang.GetLangByName("English")
+
+	custom := &lang.Language{
+		Name:      "custom",
+		NameEn:    "Custom",
+		Separator: " ",
+	}
+	for i, word := range langEn.Words {
+		custom.Words[i] = word + "q"
+	}
+
+	// Indistinguishable words
+	broken := *custom
+	broken.Words[1] = broken.Words[0]
+	if err := lang.RegisterLanguage(&broken); !errors.Is(err, lang.ErrWordlist) {
+		t.Errorf("Expected ErrWordlist for duplicate words, got %v", err)
+	}
+	broken = *custom
+	broken.HasPrefix = true
+	broken.Words[1] = "abandoned"
+	if err := lang.RegisterLanguage(&broken); !errors.Is(err, lang.ErrWordlist) {
+		t.Errorf("Expected ErrWordlist for prefix collision, got %v", err)
+	}
+	broken = *custom
+	broken.Words[7] = ""
+	if err := lang.RegisterLanguage(&broken); !errors.Is(err, lang.ErrWordlist) {
+		t.Errorf("Expected ErrWordlist for empty word, got %v", err)
+	}
+
+	// Registration is safe while phrases are decoded
+	numLangs := lang.GetNumLangs()
+	var wg sync.WaitGroup
+	wg.Go(func() {
+		for range 100 {
+			if _, _, err := lang.PhraseDecode(polyseed.SplitPhrase(phraseEn)); err != nil {
+				t.Errorf("PhraseDecode failed: %v", err)
+				return
+			}
+		}
+	})
+	register(t, custom)
+	wg.Wait()
+	if lang.GetNumLangs() != numLangs+1 {
+		t.Errorf("Expected %d languages, got %d", numLangs+1, lang.GetNumLangs())
+	}
+	if err := lang.RegisterLanguage(custom); !errors.Is(err, lang.ErrWordlist) {
+		t.Errorf("Expected ErrWordlist for duplicate registration, got %v", err)
+	}
+
+	// The custom language is used for decoding
+	seed := decodeEn(t)
+	decoded, decodedLang, err := polyseed.Decode(seed.Encode(custom, polyseed.CoinMonero), polyseed.CoinMonero)
+	if err != nil {
+		t.Fatalf("Failed to decode phrase: %v", err)
+	}
+	defer decoded.Free()
+	if decodedLang != custom || !decoded.Equal(seed) {
+		t.Error("Custom language roundtrip failed")
+	}
+
+	lang.UnregisterLanguage(custom)
+	if lang.GetNumLangs() != numLangs || lang.GetLangByName("Custom") != nil {
+		t.Error("UnregisterLanguage did not remove the language")
+	}
+}
+
+func TestPrefixLength(t *testing.T) {
+	short := &lang.Language{
+		Name:         "prefix3",
+		NameEn:       "Prefix3",
+		Separator:    " ",
+		IsSorted:     true,
+		HasPrefix:    true,
+		PrefixLength: 3,
+	}
+	for i := range short.Words {
+		short.Words[i] = fmt.Sprintf("%03xword", i)
+	}
+
+	// Unregistered languages are searched without the index
+	for _, l := range []*lang.Language{short, nil} {
+		if l == nil {
+			register(t, short)
+			l = short
+		}
+		tests := []struct {
+			word     string
+			expected int
+		}{
+			{"00aword", 10},
+			{"00a", 10},
+			{"00awo", 10},
+			{"7ffw", 2047},
+			{"00", -1},
+			{"00bx", -1},
+		}
+		for _, tt := range tests {
+			if idx := l.FindWord(tt.word); idx != tt.expected {
+				t.Errorf("FindWord(%q) = %d, expected %d", tt.word, idx, tt.expected)
+			}
+		}
+	}
+
+	seed := decodeEn(t)
+	var truncated []string
+	for _, word := range strings.Fields(seed.Encode(short, polyseed.CoinMonero)) {
+		truncated = append(truncated, word[:3])
+	}
+	decoded, decodedLang, err := polyseed.Decode(strings.Join(truncated, " "), polyseed.CoinMonero)
+	if err != nil {
+		t.Fatalf("Failed to decode truncated phrase: %v", err)
+	}
+	defer decoded.Free()
+	if decodedLang != short || !decoded.Equal(seed) {
+		t.Error("Truncated phrase roundtrip failed")
+	}
+
+	// The default prefix length is 4
+	langEn := lang.GetLangByName("English")
+	if langEn.PrefixLength != 0 || langEn.FindWord("rav") >= 0 || langEn.FindWord("rave") < 0 {
+		t.Error("Unexpected English prefix matching")
+	}
+
+	broken := *short
+	broken.NameEn = "Broken"
+	broken.PrefixLength = -1
+	if err := broken.Verify(); !errors.Is(err, lang.ErrWordlist) {
+		t.Errorf("negative prefix length: expected ErrWordlist, got %v", err)
+	}
+	broken.PrefixLength = 2
+	if err := broken.Verify(); !errors.Is(err, lang.ErrWordlist) {
+		t.Errorf("ambiguous prefixes: expected ErrWordlist, got %v", err)
+	}
+}
+
+func TestDecodeHint(t *testing.T) {
+	// Two languages with the same words make every phrase ambiguous
+	var twins []*lang.Language
+	for _, name := range []string{"Hint A", "Hint B"} {
+		l := &lang.Language{
+			Name:      strings.ToLower(name),
+			NameEn:    name,
+			Separator: " ",
+			IsSorted:  true,
+		}
+		for i := range l.Words {
+			l.Words[i] = fmt.Sprintf("hint%04d", i)
+		}
+		register(t, l)
+		twins = append(twins, l)
+	}
+
+	seed := decodeEn(t)
+	phrase := seed.Encode(twins[0], polyseed.CoinMonero)
+
+	if _, _, err := polyseed.Decode(phrase, polyseed.CoinMonero); err != polyseed.StatusErrMultLang {
+		t.Fatalf("Expected StatusErrMultLang, got %v", err)
+	}
+	for _, preferred := range twins {
+		decoded, decodedLang, err := polyseed.DecodeHint(phrase, polyseed.CoinMonero, preferred)
+		if err != nil {
+			t.Fatalf("DecodeHint with %s failed: %v", preferred.NameEn, err)
+		}
+		if decodedLang != preferred || !decoded.Equal(seed) {
+			t.Errorf("DecodeHint with %s: got %s", preferred.NameEn, decodedLang.NameEn)
+		}
+		decoded.Free()
+	}
+	for _, preferred := range []*lang.Language{nil, lang.GetLangByName("English")} {
+		if _, _, err := polyseed.DecodeHint(phrase, polyseed.CoinMonero, preferred); err != polyseed.StatusErrMultLang {
+			t.Errorf("DecodeHint with %v: expected StatusErrMultLang, got %v", preferred, err)
+		}
+	}
+
+	// The hint does not override an unambiguous phrase
+	decoded, decodedLang, err := polyseed.DecodeHint(phraseEn, polyseed.CoinMonero, twins[0])
+	if err != nil || decodedLang != lang.GetLangByName("English") {
+		t.Fatalf("DecodeHint of an English phrase: %v, %v", decodedLang, err)
+	}
+	decoded.Free()
+}
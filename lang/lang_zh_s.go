@@ -7,6 +7,7 @@ package lang
 var LangZhS = Language{
 	Name:       "中文(简体)",
 	NameEn:     "Chinese (Simplified)",
+	Code:       "zh-Hans",
 	Separator:  " ",
 	IsSorted:   false,
 	HasPrefix:  false,
@@ -270,4 +271,5 @@ var LangZhS = Language{
 		"祸", "丘", "玄", "溜", "曰", "逻", "彭", "尝",
 		"卿", "妨", "艇", "吞", "韦", "怨", "矮", "歇",
 	},
+	NoSpaceSplit: true,
 }
\ No newline at end of file
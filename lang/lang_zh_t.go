@@ -7,6 +7,7 @@ package lang
 var LangZhT = Language{
 	Name:       "中文(繁體)",
 	NameEn:     "Chinese (Traditional)",
+	Code:       "zh-Hant",
 	Separator:  " ",
 	IsSorted:   false,
 	HasPrefix:  false,
@@ -270,4 +271,5 @@ var LangZhT = Language{
 		"禍", "丘", "玄", "溜", "曰", "邏", "彭", "嘗",
 		"卿", "妨", "艇", "吞", "韋", "怨", "矮", "歇",
 	},
+	NoSpaceSplit: true,
 }
\ No newline at end of file
@@ -0,0 +1,54 @@
+// Copyright (c) 2025-2026 complex (complex@ft.hn)
+// See LICENSE for licensing information
+
+package polyseed
+
+import (
+	"github.com/complex-gh/polyseed_go/internal"
+	"github.com/complex-gh/polyseed_go/lang"
+)
+
+// PhraseToPolynomial returns the polynomial of a phrase over GF(2048),
+// for analysis and custom error correction. words are the 16 words of the
+// phrase in the given language.
+//
+// Coefficient i is the wordlist index of word i, with the coin added to
+// coefficient 1. The checksum is not verified; use PolynomialChecksumOK.
+//
+// Returns an error if the words are not valid in the language or the coin
+// is out of range.
+func PhraseToPolynomial(words []string, lang *lang.Language, coin Coin) ([NumWords]uint16, error) {
+	var coeffs [NumWords]uint16
+	if len(words) != NumWords {
+		return coeffs, numWordsError(len(words), NumWords)
+	}
+	if coin > internal.GfMask {
+		return coeffs, StatusErrUnsupported
+	}
+
+	for i, word := range words {
+		idx := lang.FindWord(UTF8NFKDLazy(word))
+		if idx < 0 {
+			return [NumWords]uint16{}, StatusErrLang
+		}
+		coeffs[i] = uint16(idx)
+	}
+	coeffs[internal.PolyNumCheckDigits] ^= uint16(coin)
+
+	return coeffs, nil
+}
+
+// PolynomialChecksumOK reports whether a polynomial returned by
+// PhraseToPolynomial satisfies the checksum. Coefficients outside of
+// GF(2048) never do.
+func PolynomialChecksumOK(coeffs [NumWords]uint16) bool {
+	p := &internal.GfPoly{}
+	defer wipePoly(p)
+	for i, c := range coeffs {
+		if c > internal.GfMask {
+			return false
+		}
+		p.Coeff[i] = internal.GfElem(c)
+	}
+	return p.Check()
+}
@@ -6,6 +6,11 @@ package polyseed
 import (
 	"crypto/rand"
 	"crypto/sha256"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"io"
+	"runtime"
 	"strings"
 	"time"
 
@@ -24,6 +29,9 @@ const (
 	// StorageSize is the size of the serialized seed
 	StorageSize = 32
 
+	// CompactSize is the size of the compact serialized seed
+	CompactSize = internal.CompactSize
+
 	// StrSize is the maximum possible length of a mnemonic phrase
 	StrSize = 360
 
@@ -101,6 +109,89 @@ func (s Status) Error() string {
 	}
 }
 
+// Unwrap returns the lang package error a language status was translated
+// from, so that errors.Is matches both the Status and its cause:
+// StatusErrLang wraps lang.ErrLang and StatusErrMultLang wraps
+// lang.ErrMultLang. Other statuses have no cause and return nil.
+//
+// The cause is implied by the status, so Status values still compare equal
+// with == and errors.As into a Status keeps working.
+func (s Status) Unwrap() error {
+	switch s {
+	case StatusErrLang:
+		return lang.ErrLang
+	case StatusErrMultLang:
+		return lang.ErrMultLang
+	default:
+		return nil
+	}
+}
+
+// NumWordsError reports a phrase with the wrong number of words. It
+// unwraps to StatusErrNumWords, so errors.Is matches it like the bare
+// status.
+type NumWordsError struct {
+	// Got is the number of words found in the phrase
+	Got int
+
+	// Expected is the number of words the operation requires
+	Expected int
+}
+
+// Error returns the status message with the word counts
+func (e *NumWordsError) Error() string {
+	return fmt.Sprintf("%v: got %d, expected %d", StatusErrNumWords, e.Got, e.Expected)
+}
+
+// Unwrap returns StatusErrNumWords
+func (e *NumWordsError) Unwrap() error {
+	return StatusErrNumWords
+}
+
+// numWordsError returns a NumWordsError for a phrase of got words
+func numWordsError(got, expected int) error {
+	return &NumWordsError{Got: got, Expected: expected}
+}
+
+var (
+	// ErrEmptyPassword indicates an empty or whitespace-only password
+	ErrEmptyPassword = errors.New("empty password")
+
+	// ErrVanityNotFound indicates no seed with the desired checksum word was found
+	ErrVanityNotFound = errors.New("no seed with the desired checksum word found")
+
+	// ErrPath indicates a malformed key derivation path
+	ErrPath = errors.New("invalid derivation path")
+
+	// ErrKeySize indicates a negative or too large key size
+	ErrKeySize = errors.New("invalid key size")
+
+	// ErrNotEncrypted indicates an operation that requires an encrypted seed
+	ErrNotEncrypted = errors.New("seed is not encrypted")
+
+	// ErrEncrypted indicates an operation that requires an unencrypted seed
+	ErrEncrypted = errors.New("seed is already encrypted")
+
+	// ErrIterations indicates a negative KDF iteration count
+	ErrIterations = errors.New("invalid iteration count")
+
+	// ErrCoin indicates an unknown or invalid coin
+	ErrCoin = errors.New("invalid coin")
+
+	// ErrBirthday indicates a timestamp too far in the future for a birthday
+	ErrBirthday = errors.New("birthday out of range")
+
+	// ErrWordIndex indicates a word position outside of the phrase
+	ErrWordIndex = errors.New("word index out of range")
+
+	// ErrLineTooLong indicates an input line too long to be a phrase
+	ErrLineTooLong = errors.New("line too long")
+
+	// ErrTestVector indicates a test vector that the implementation does not
+	// reproduce
+	ErrTestVector = errors.New("test vector mismatch")
+)
+
 // Storage is the serialized seed format. The contents are platform-independent.
 type Storage [StorageSize]byte
 
@@ -110,6 +201,7 @@ type Seed struct {
 	features  uint8
 	secret    [32]byte
 	checksum  uint16
+	keys      map[keyCacheKey][]byte
 }
 
 // toData converts a Seed to internal data format
@@ -124,31 +216,35 @@ func (s *Seed) toData() *internal.Data {
 
 // seedFromData creates a Seed from internal data format
 func seedFromData(d *internal.Data) *Seed {
-	return &Seed{
+	return trackSeed(&Seed{
 		birthday: d.Birthday,
 		features: d.Features,
 		secret:   d.Secret,
 		checksum: d.Checksum,
-	}
+	})
 }
 
 
-var (
-	// reservedFeatures tracks which feature bits are reserved
-	reservedFeatures uint8 = FeatureMask ^ encryptedMask
-)
-
-// memzero securely erases memory by overwriting it with zeros
+// memzero securely erases memory by overwriting it with zeros. It is never
+// inlined and keeps the buffer alive until the stores are done, so the
+// compiler cannot eliminate them as dead writes.
+//
+//go:noinline
 func memzero(b []byte) {
 	for i := range b {
 		b[i] = 0
 	}
+	runtime.KeepAlive(b)
 }
 
-// getRandomBytes generates cryptographically secure random bytes
-func getRandomBytes(b []byte) error {
-	_, err := rand.Read(b)
-	return err
+// wipePoly erases the polynomial coefficients, which encode the secret
+//
+//go:noinline
+func wipePoly(p *internal.GfPoly) {
+	for i := range p.Coeff {
+		p.Coeff[i] = 0
+	}
+	runtime.KeepAlive(p)
 }
 
 // pbkdf2SHA256 calculates PBKDF2 based on HMAC-SHA256
@@ -177,6 +273,18 @@ func UTF8NFKDLazy(str string) string {
 	return str
 }
 
+// NormalizePhrase converts a mnemonic phrase to the decomposed canonical
+// form (NFKD) used for matching words
+func NormalizePhrase(str string) string {
+	return UTF8NFKDLazy(str)
+}
+
+// SplitPhrase normalizes a mnemonic phrase like NormalizePhrase and splits
+// it into words
+func SplitPhrase(str string) []string {
+	return lang.SplitPhrase(str)
+}
+
 // getTime returns the current unix time
 func getTime() uint64 {
 	return uint64(time.Now().Unix())
@@ -193,36 +301,39 @@ const (
 //
 // Returns the seed and an error if the operation failed.
 func Create(features uint8) (*Seed, error) {
-	// Check features
-	seedFeatures := makeFeatures(features)
-	if !featuresSupported(seedFeatures) {
-		return nil, StatusErrUnsupported
-	}
+	return CreateWithReader(rand.Reader, features)
+}
 
-	// Create seed
-	seed := &Seed{
-		birthday: birthdayEncode(getTime()),
-		features: seedFeatures,
+// MustCreate is like Create but panics if the seed cannot be created. It
+// simplifies initialization code that cannot proceed without a seed.
+func MustCreate(features uint8) *Seed {
+	seed, err := Create(features)
+	if err != nil {
+		panic("polyseed: Create: " + err.Error())
 	}
+	return seed
+}
 
-	// Generate random secret
-	if err := getRandomBytes(seed.secret[:internal.SecretSize]); err != nil {
+// CreateWithReader creates a new seed with specific features, reading the
+// secret from r instead of the system random number generator. r should be
+// a cryptographically secure source.
+//
+// Returns the seed and an error if the operation failed. A short read from
+// r fails with StatusErrMemory.
+func CreateWithReader(r io.Reader, features uint8) (*Seed, error) {
+	return createFromReader(r, features, defaultFeatureSet())
+}
+
+// createFromReader creates a seed with a secret read from r
+func createFromReader(r io.Reader, features uint8, fs FeatureSet) (*Seed, error) {
+	// Read random secret
+	var secret [internal.SecretSize]byte
+	if _, err := io.ReadFull(r, secret[:]); err != nil {
 		return nil, StatusErrMemory
 	}
-	seed.secret[internal.SecretSize-1] &= internal.ClearMask
-
-	// Encode polynomial
-	d := seed.toData()
-	p := &internal.GfPoly{}
-	internal.DataToPoly(d, p)
+	defer memzero(secret[:])
 
-	// Calculate checksum
-	p.Encode()
-	seed.checksum = uint16(p.Coeff[0])
-
-	memzero(d.Secret[:])
-
-	return seed, nil
+	return createSeed(secret[:], birthdayEncode(getTime()), features, fs)
 }
 
 // CreateFromBytes creates a new seed from existing secret bytes.
@@ -237,21 +348,53 @@ func CreateFromBytes(secretBytes []byte, features uint8) (*Seed, error) {
 	if len(secretBytes) < internal.SecretSize {
 		return nil, StatusErrFormat
 	}
+	return createSeed(secretBytes[:internal.SecretSize], birthdayEncode(getTime()), features, defaultFeatureSet())
+}
+
+// CreateFromEntropy creates a new seed from caller-supplied entropy.
+// The entropy must be exactly 19 bytes (150 bits are used, with the last
+// byte masked appropriately). The birthday is set to the current time.
+//
+// features are the values of the boolean features for this seed. Only
+// the least significant 3 bits are used.
+//
+// Returns the seed and an error if the operation failed.
+func CreateFromEntropy(entropy []byte, features uint8) (*Seed, error) {
+	if len(entropy) != internal.SecretSize {
+		return nil, StatusErrFormat
+	}
+	return createSeed(entropy, birthdayEncode(getTime()), features, defaultFeatureSet())
+}
+
+// CreateWithBirthday creates a new seed from caller-supplied entropy and an
+// explicit creation timestamp. It is like CreateFromEntropy, but the birthday
+// is taken from timestamp instead of the current time. Timestamps before the
+// polyseed epoch are stored as the epoch.
+//
+// Returns the seed and an error if the operation failed.
+func CreateWithBirthday(entropy []byte, timestamp uint64, features uint8) (*Seed, error) {
+	if len(entropy) != internal.SecretSize {
+		return nil, StatusErrFormat
+	}
+	return createSeed(entropy, birthdayEncode(timestamp), features, defaultFeatureSet())
+}
 
+// createSeed creates a seed from a secret of SecretSize bytes
+func createSeed(secret []byte, birthday uint16, features uint8, fs FeatureSet) (*Seed, error) {
 	// Check features
 	seedFeatures := makeFeatures(features)
-	if !featuresSupported(seedFeatures) {
+	if !fs.supported(seedFeatures) {
 		return nil, StatusErrUnsupported
 	}
 
 	// Create seed
-	seed := &Seed{
-		birthday: birthdayEncode(getTime()),
+	seed := trackSeed(&Seed{
+		birthday: birthday,
 		features: seedFeatures,
-	}
+	})
 
 	// Copy secret bytes
-	copy(seed.secret[:internal.SecretSize], secretBytes[:internal.SecretSize])
+	copy(seed.secret[:internal.SecretSize], secret)
 	seed.secret[internal.SecretSize-1] &= internal.ClearMask
 
 	// Encode polynomial
@@ -264,13 +407,43 @@ func CreateFromBytes(secretBytes []byte, features uint8) (*Seed, error) {
 	seed.checksum = uint16(p.Coeff[0])
 
 	memzero(d.Secret[:])
+	wipePoly(p)
 
 	return seed, nil
 }
 
+// CreateVanity creates a new seed whose checksum word is the desired word.
+//
+// The checksum word is the first word of the phrase. It does not depend on
+// the coin. Each attempt generates a fresh random seed, so about 1 in 2048
+// attempts succeeds and maxTries should be set accordingly.
+//
+// Returns the seed and an error if the word is not in the language or
+// maxTries attempts were exhausted.
+func CreateVanity(features uint8, desiredChecksumWord string, lang *lang.Language, maxTries int) (*Seed, error) {
+	idx := lang.FindWord(UTF8NFKDLazy(desiredChecksumWord))
+	if idx < 0 {
+		return nil, StatusErrLang
+	}
+
+	for i := 0; i < maxTries; i++ {
+		seed, err := Create(features)
+		if err != nil {
+			return nil, err
+		}
+		if seed.checksum == uint16(idx) {
+			return seed, nil
+		}
+		seed.Free()
+	}
+
+	return nil, ErrVanityNotFound
+}
+
 // Free securely erases the seed data
 func (s *Seed) Free() {
 	memzero(s.secret[:])
+	s.wipeKeys()
 }
 
 // GetBirthday gets the approximate date when the seed was created
@@ -283,59 +456,266 @@ func (s *Seed) GetFeature(mask uint8) uint8 {
 	return getFeatures(s.features, mask)
 }
 
-// Encode encodes the mnemonic seed into a string
-func (s *Seed) Encode(lang *lang.Language, coin Coin) string {
+// HasFeature reports whether any of the seed feature flags in mask is set
+func (s *Seed) HasFeature(mask uint8) bool {
+	return getFeatures(s.features, mask) != 0
+}
+
+// SetFeature sets or clears user feature flags and recomputes the checksum.
+//
+// mask selects the features to change. It must only contain user feature
+// bits that are enabled, otherwise StatusErrUnsupported is returned.
+func (s *Seed) SetFeature(mask uint8, enabled bool) error {
+	if mask&^userFeaturesMask != 0 || !featuresSupported(mask) {
+		return StatusErrUnsupported
+	}
+
+	if enabled {
+		s.features |= mask
+	} else {
+		s.features &^= mask
+	}
+	s.updateChecksum()
+
+	return nil
+}
+
+// updateChecksum recomputes the checksum after the seed data was changed
+func (s *Seed) updateChecksum() {
+	s.wipeKeys()
 	d := s.toData()
 	p := &internal.GfPoly{}
+	internal.DataToPoly(d, p)
+	p.Encode()
+	s.checksum = uint16(p.Coeff[0])
+	memzero(d.Secret[:])
+	wipePoly(p)
+}
+
+// Secret returns a copy of the 19-byte seed secret. The caller owns the
+// copy and should erase it when done.
+func (s *Seed) Secret() []byte {
+	secret := make([]byte, internal.SecretSize)
+	copy(secret, s.secret[:internal.SecretSize])
+	return secret
+}
+
+// Entropy returns the raw secret of the seed, for use with other
+// entropy-based systems. Unlike Keygen, the secret is not hashed.
+//
+// The secret has 150 bits, which is not a BIP39 entropy size. It is
+// returned as 19 bytes; the two most significant bits of the last byte are
+// always zero. If the seed is encrypted, the encrypted secret is returned.
+//
+// Entropy is the inverse of CreateFromEntropy. The birthday and features
+// are not part of the entropy; CreateWithBirthday recreates the same seed.
+func (s *Seed) Entropy() []byte {
+	return s.Secret()
+}
+
+// Checksum returns the 11-bit checksum of the seed, as stored in the
+// footer of the serialized seed
+func (s *Seed) Checksum() uint16 {
+	return s.checksum
+}
+
+// Clone returns a deep copy of the seed. Freeing either seed does not
+// affect the other.
+func (s *Seed) Clone() *Seed {
+	clone := *s
+	clone.keys = nil
+	return trackSeed(&clone)
+}
+
+// Equal reports whether two seeds are identical. The secrets are compared
+// in constant time.
+func (s *Seed) Equal(other *Seed) bool {
+	if other == nil {
+		return false
+	}
+	eq := subtle.ConstantTimeCompare(s.secret[:], other.secret[:])
+	eq &= subtle.ConstantTimeEq(int32(s.birthday), int32(other.birthday))
+	eq &= subtle.ConstantTimeEq(int32(s.features), int32(other.features))
+	eq &= subtle.ConstantTimeEq(int32(s.checksum), int32(other.checksum))
+	return eq == 1
+}
+
+// Validate checks that the seed is internally consistent, with the same
+// checks as Load: the checksum must match the polynomial rebuilt from the
+// seed data, and the features must be enabled.
+//
+// Returns StatusErrFormat if a field is out of range or the secret has bits
+// set beyond its 150 bits, StatusErrChecksum if the checksum does not match
+// and StatusErrUnsupported if the seed uses features that are not enabled.
+func (s *Seed) Validate() error {
+	if s.birthday > DateMask || s.features > FeatureMask || s.checksum > internal.GfMask {
+		return StatusErrFormat
+	}
+	if s.secret[internal.SecretSize-1]&^internal.ClearMask != 0 {
+		return StatusErrFormat
+	}
+	for _, b := range s.secret[internal.SecretSize:] {
+		if b != 0 {
+			return StatusErrFormat
+		}
+	}
+
+	d := s.pooledData()
+	defer putData(d)
+	return verifyData(d)
+}
+
+// Snapshot returns a copy of the seed by value.
+//
+// The copy does not share memory with the original, so it can be read
+// concurrently from multiple goroutines (Encode, Keygen, GetBirthday, ...)
+// while the original remains the mutable owner. Free must be called on
+// both the original and the copy.
+func (s *Seed) Snapshot() Seed {
+	snapshot := *s
+	snapshot.keys = nil
+	return snapshot
+}
+
+// Encode encodes the mnemonic seed into a string.
+//
+// Returns an empty string if the coin is out of range; use EncodeChecked to
+// get an error instead.
+func (s *Seed) Encode(lang *lang.Language, coin Coin) string {
+	return s.EncodeWithSeparator(lang, coin, lang.Separator)
+}
+
+// EncodeChecked encodes the mnemonic seed into a string like Encode, but
+// returns StatusErrUnsupported if the coin is out of range instead of
+// producing a corrupted phrase.
+func (s *Seed) EncodeChecked(lang *lang.Language, coin Coin) (string, error) {
+	if coin > internal.GfMask {
+		return "", StatusErrUnsupported
+	}
+	return s.Encode(lang, coin), nil
+}
+
+// EncodeWithSeparator encodes the mnemonic seed into a string like Encode,
+// but joins the words with sep instead of the language separator.
+//
+// Decode splits phrases on whitespace, so phrases joined with other
+// separators (for example commas) must be split by the caller.
+//
+// Returns an empty string if the coin is out of range.
+func (s *Seed) EncodeWithSeparator(lang *lang.Language, coin Coin, sep string) string {
+	if coin > internal.GfMask {
+		return ""
+	}
+	var p internal.GfPoly
+	s.phrasePoly(coin, &p)
+	phrase := polyToPhrase(&p, lang, sep)
+	wipePoly(&p)
+
+	return phrase
+}
+
+// EncodeIndices returns the wordlist indices of the words of the mnemonic
+// phrase, for UIs that render the words themselves, e.g. as numbered
+// tiles. Index i is the position of word i in the wordlist of any language.
+//
+// The indices encode the secret like the phrase itself; the caller should
+// wipe them after use.
+//
+// Returns StatusErrUnsupported if the coin is out of range.
+func (s *Seed) EncodeIndices(coin Coin) ([NumWords]uint16, error) {
+	var indices [NumWords]uint16
+	if coin > internal.GfMask {
+		return indices, StatusErrUnsupported
+	}
+	var p internal.GfPoly
+	s.phrasePoly(coin, &p)
+	for i := range indices {
+		indices[i] = uint16(p.Coeff[i])
+	}
+	wipePoly(&p)
+
+	return indices, nil
+}
+
+// phrasePoly fills p with the polynomial of the seed with the coin applied,
+// whose coefficients are the word indices of the phrase
+func (s *Seed) phrasePoly(coin Coin, p *internal.GfPoly) {
+	d := s.toData()
 	p.Coeff[0] = internal.GfElem(d.Checksum)
 	internal.DataToPoly(d, p)
 
 	// Apply coin
 	p.Coeff[internal.PolyNumCheckDigits] ^= internal.GfElem(coin)
 
+	memzero(d.Secret[:])
+}
+
+// polyToPhrase builds a mnemonic phrase from the polynomial coefficients
+func polyToPhrase(p *internal.GfPoly, lang *lang.Language, sep string) string {
 	// Build phrase
 	var words []string
 	for i := 0; i < NumWords; i++ {
 		words = append(words, lang.Words[p.Coeff[i]])
 	}
 
-	phrase := strings.Join(words, lang.Separator)
+	phrase := strings.Join(words, sep)
 
 	// Compose if needed by the language
 	if lang.Compose {
 		phrase = utf8NFC(phrase)
 	}
 
-	memzero(d.Secret[:])
-
 	return phrase
 }
 
-// Decode decodes the seed from a mnemonic phrase
-func Decode(str string, coin Coin) (*Seed, *lang.Language, error) {
-	// Canonical decomposition
-	strNorm := UTF8NFKDLazy(str)
+// langError translates an error of the lang package to a Status
+func langError(err error) error {
+	switch {
+	case errors.Is(err, lang.ErrMultLang):
+		return StatusErrMultLang
+	case errors.Is(err, lang.ErrLang):
+		return StatusErrLang
+	default:
+		return err
+	}
+}
 
-	// Split into words
-	words := lang.SplitPhrase(strNorm)
-	if len(words) != NumWords {
-		return nil, nil, StatusErrNumWords
+// phraseToPoly splits a mnemonic phrase and decodes its words into
+// polynomial coefficients, auto-detecting the language. The checksum
+// is not verified.
+func phraseToPoly(str string, coin Coin) (*internal.GfPoly, *lang.Language, error) {
+	if coin > internal.GfMask {
+		return nil, nil, StatusErrUnsupported
 	}
 
-	// Decode words into polynomial coefficients
-	indices, foundLang, err := lang.PhraseDecode(words)
-	if err != nil {
+	// Split into words
+	words := SplitPhrase(str)
+	var indices []uint16
+	var foundLang *lang.Language
+	var err error
+	switch {
+	case len(words) > 0 && len(words) < NumWords:
+		// Phrase written without some or all separators
+		indices, foundLang, err = lang.PhraseDecodeJoined(words...)
 		if err == lang.ErrLang {
-			return nil, nil, StatusErrLang
+			return nil, nil, numWordsError(len(words), NumWords)
 		}
-		if err == lang.ErrMultLang {
-			return nil, nil, StatusErrMultLang
-		}
-		return nil, nil, err
+	case len(words) == NumWords:
+		// Decode words into polynomial coefficients
+		indices, foundLang, err = lang.PhraseDecode(words)
+	default:
+		return nil, nil, numWordsError(len(words), NumWords)
+	}
+	if err != nil {
+		return nil, nil, langError(err)
 	}
 
-	// Build polynomial
-	p := &internal.GfPoly{}
+	return indicesToPoly(indices, coin), foundLang, nil
+}
+
+// indicesToPoly builds the polynomial of the word indices of a phrase
+func indicesToPoly(indices []uint16, coin Coin) *internal.GfPoly {
+	p := getPoly()
 	for i, idx := range indices {
 		p.Coeff[i] = internal.GfElem(idx)
 	}
@@ -343,6 +723,64 @@ func Decode(str string, coin Coin) (*Seed, *lang.Language, error) {
 	// Finalize polynomial
 	p.Coeff[internal.PolyNumCheckDigits] ^= internal.GfElem(coin)
 
+	return p
+}
+
+// Decode decodes the seed from a mnemonic phrase
+func Decode(str string, coin Coin) (*Seed, *lang.Language, error) {
+	return decode(str, coin, defaultFeatureSet())
+}
+
+// MustDecode is like Decode but panics if the phrase cannot be decoded. It
+// simplifies tests and initialization code with known phrases.
+func MustDecode(str string, coin Coin) (*Seed, *lang.Language) {
+	seed, foundLang, err := Decode(str, coin)
+	if err != nil {
+		panic("polyseed: Decode: " + err.Error())
+	}
+	return seed, foundLang
+}
+
+// DecodeWords decodes the seed from the words of a mnemonic phrase, for
+// callers that already have the words separately, e.g. from one input box
+// per word. Each word is normalized like in Decode.
+//
+// Unlike Decode, phrases written without separators are not split; words
+// must contain exactly 16 elements.
+func DecodeWords(words []string, coin Coin) (*Seed, *lang.Language, error) {
+	if coin > internal.GfMask {
+		return nil, nil, StatusErrUnsupported
+	}
+	if len(words) != NumWords {
+		return nil, nil, numWordsError(len(words), NumWords)
+	}
+
+	normalized := make([]string, len(words))
+	for i, word := range words {
+		normalized[i] = UTF8NFKDLazy(strings.TrimSpace(word))
+	}
+	indices, foundLang, err := lang.PhraseDecode(normalized)
+	if err != nil {
+		return nil, nil, langError(err)
+	}
+
+	return decodePoly(indicesToPoly(indices, coin), foundLang, defaultFeatureSet())
+}
+
+// decode decodes the seed from a mnemonic phrase with a set of enabled features
+func decode(str string, coin Coin, fs FeatureSet) (*Seed, *lang.Language, error) {
+	p, foundLang, err := phraseToPoly(str, coin)
+	if err != nil {
+		return nil, nil, err
+	}
+	return decodePoly(p, foundLang, fs)
+}
+
+// decodePoly decodes the seed from the polynomial of a phrase and releases
+// the polynomial
+func decodePoly(p *internal.GfPoly, foundLang *lang.Language, fs FeatureSet) (*Seed, *lang.Language, error) {
+	defer putPoly(p)
+
 	// Check checksum
 	if !p.Check() {
 		return nil, nil, StatusErrChecksum
@@ -353,7 +791,7 @@ func Decode(str string, coin Coin) (*Seed, *lang.Language, error) {
 	internal.PolyToData(p, d)
 
 	// Check features
-	if !featuresSupported(d.Features) {
+	if !fs.supported(d.Features) {
 		memzero(d.Secret[:])
 		return nil, nil, StatusErrUnsupported
 	}
@@ -363,15 +801,64 @@ func Decode(str string, coin Coin) (*Seed, *lang.Language, error) {
 	return seed, foundLang, nil
 }
 
+// ValidatePhrase checks that a mnemonic phrase is valid without decoding
+// the seed. It returns the same errors as Decode.
+func ValidatePhrase(str string, coin Coin) error {
+	p, _, err := phraseToPoly(str, coin)
+	if err != nil {
+		return err
+	}
+	defer putPoly(p)
+
+	// Check checksum
+	if !p.Check() {
+		return StatusErrChecksum
+	}
+
+	// Check features
+	if features, _ := internal.PolyMeta(p); !featuresSupported(features) {
+		return StatusErrUnsupported
+	}
+
+	return nil
+}
+
+// DecodeHint decodes the seed from a mnemonic phrase like Decode, but
+// resolves ambiguity with a preferred language: if the phrase matches more
+// than one language and preferred is one of them, preferred is used. A
+// phrase that matches a single language decodes in that language, even if
+// it is not preferred.
+//
+// Returns StatusErrMultLang if the phrase is ambiguous and does not decode
+// with preferred.
+func DecodeHint(str string, coin Coin, preferred *lang.Language) (*Seed, *lang.Language, error) {
+	seed, foundLang, err := Decode(str, coin)
+	if err != StatusErrMultLang || preferred == nil {
+		return seed, foundLang, err
+	}
+	seed, hintErr := DecodeExplicit(str, coin, preferred)
+	if hintErr != nil {
+		return nil, nil, err
+	}
+	return seed, preferred, nil
+}
+
 // DecodeExplicit decodes the seed from a mnemonic phrase with a specific language
 func DecodeExplicit(str string, coin Coin, foundLang *lang.Language) (*Seed, error) {
-	// Canonical decomposition
-	strNorm := UTF8NFKDLazy(str)
+	if coin > internal.GfMask {
+		return nil, StatusErrUnsupported
+	}
 
 	// Split into words
-	words := lang.SplitPhrase(strNorm)
+	words := SplitPhrase(str)
+	if len(words) > 0 && len(words) < NumWords {
+		// Phrase written without some or all separators
+		if segmented := lang.SegmentParts(words, foundLang); segmented != nil {
+			words = segmented
+		}
+	}
 	if len(words) != NumWords {
-		return nil, StatusErrNumWords
+		return nil, numWordsError(len(words), NumWords)
 	}
 
 	// Decode words into polynomial coefficients
@@ -385,6 +872,7 @@ func DecodeExplicit(str string, coin Coin, foundLang *lang.Language) (*Seed, err
 
 	// Build polynomial
 	p := &internal.GfPoly{}
+	defer wipePoly(p)
 	for i, idx := range indices {
 		p.Coeff[i] = internal.GfElem(idx)
 	}
@@ -412,6 +900,26 @@ func DecodeExplicit(str string, coin Coin, foundLang *lang.Language) (*Seed, err
 	return seed, nil
 }
 
+// PhrasesEquivalent reports whether two mnemonic phrases decode to the
+// same seed, for example when a phrase is retyped without accents or in a
+// different normalization form. The phrases may be in different languages.
+// The seeds are compared in constant time.
+//
+// Returns an error if either phrase cannot be decoded.
+func PhrasesEquivalent(a, b string, coin Coin) (bool, error) {
+	seedA, _, err := Decode(a, coin)
+	if err != nil {
+		return false, err
+	}
+	defer seedA.Free()
+	seedB, _, err := Decode(b, coin)
+	if err != nil {
+		return false, err
+	}
+	defer seedB.Free()
+	return seedA.Equal(seedB), nil
+}
+
 // store32 stores a 32-bit value in little-endian format
 func store32(p []byte, u uint32) {
 	p[0] = byte(u)
@@ -423,10 +931,8 @@ func store32(p []byte, u uint32) {
 	p[3] = byte(u)
 }
 
-// Keygen derives a secret key from the mnemonic seed
-func (s *Seed) Keygen(coin Coin, keySize int) []byte {
-	d := s.toData()
-
+// keygenSalt builds the PBKDF2 salt used by Keygen
+func keygenSalt(d *internal.Data, coin Coin) []byte {
 	salt := make([]byte, 32)
 	copy(salt, "POLYSEED key")
 	salt[13] = 0xFF
@@ -442,27 +948,40 @@ func (s *Seed) Keygen(coin Coin, keySize int) []byte {
 	// Domain separate by features (32-bit)
 	store32(salt[24:], uint32(d.Features))
 
-	// Use full secret buffer (32 bytes) for PBKDF2
-	key := pbkdf2SHA256(d.Secret[:], salt, kdfNumIterations, keySize)
-
-	memzero(d.Secret[:])
+	return salt
+}
 
-	return key
+// Keygen derives a secret key from the mnemonic seed. The caller should
+// wipe the key with Zero after use.
+func (s *Seed) Keygen(coin Coin, keySize int) SecretBytes {
+	return s.KeygenWithKDF(coin, keySize, defaultKDF)
 }
 
-// Crypt encrypts or decrypts the seed data with a password
-func (s *Seed) Crypt(password string) {
-	d := s.toData()
+// Crypt encrypts or decrypts the seed data with a password.
+//
+// An empty or whitespace-only password provides no protection, so it is
+// rejected with ErrEmptyPassword. Use CryptAllowEmpty to bypass the check.
+func (s *Seed) Crypt(password string) error {
+	return s.CryptWithKDF(password, defaultKDF)
+}
 
-	// Normalize password (NFKD decomposition)
-	passNorm := utf8NFKD(password)
-	passBytes := []byte(passNorm)
+// CryptAllowEmpty encrypts or decrypts the seed data with a password,
+// accepting an empty password
+func (s *Seed) CryptAllowEmpty(password string) {
+	s.crypt(utf8NFKD(password), defaultKDF)
+}
 
-	// Derive an encryption mask
-	salt := []byte("POLYSEED mask")
-	salt = append(salt, 0xFF, 0xFF)
+// crypt applies the encryption mask derived from a normalized password
+func (s *Seed) crypt(passNorm string, kdf KDF) {
+	mask := cryptMask(passNorm, kdf)
+	s.applyMask(mask)
+	memzero(mask)
+}
 
-	mask := pbkdf2SHA256(passBytes, salt, kdfNumIterations, 32)
+// applyMask encrypts or decrypts the seed data with an encryption mask
+func (s *Seed) applyMask(mask []byte) {
+	s.wipeKeys()
+	d := s.toData()
 
 	// Apply mask
 	for i := 0; i < internal.SecretSize; i++ {
@@ -484,7 +1003,74 @@ func (s *Seed) Crypt(password string) {
 	copy(s.secret[:], d.Secret[:])
 
 	memzero(d.Secret[:])
-	memzero(mask)
+	wipePoly(p)
+}
+
+// Encrypt encrypts the seed with a password. Unlike Crypt, it cannot
+// toggle the encryption off.
+//
+// Returns ErrEncrypted if the seed is already encrypted and
+// ErrEmptyPassword if the password is empty.
+func (s *Seed) Encrypt(password string) error {
+	if s.IsEncrypted() {
+		return ErrEncrypted
+	}
+	return s.Crypt(password)
+}
+
+// Decrypt decrypts an encrypted seed with a password. Unlike Crypt, it
+// cannot accidentally encrypt the seed a second time.
+//
+// Polyseed has no authentication tag, so a wrong password cannot be
+// detected: it produces a valid seed with a different secret, and Decrypt
+// succeeds. Returns ErrNotEncrypted if the seed is not encrypted.
+func (s *Seed) Decrypt(password string) error {
+	if !s.IsEncrypted() {
+		return ErrNotEncrypted
+	}
+	s.crypt(utf8NFKD(password), defaultKDF)
+	return nil
+}
+
+// cryptMask derives the encryption mask from a normalized password
+func cryptMask(passNorm string, kdf KDF) []byte {
+	return kdf.Derive([]byte(passNorm), cryptSalt(), 32)
+}
+
+// cryptSalt returns the salt of the encryption mask
+func cryptSalt() []byte {
+	salt := []byte("POLYSEED mask")
+	return append(salt, 0xFF, 0xFF)
+}
+
+// ChangePassword re-encrypts an encrypted seed under a new password. The
+// secret is never decrypted in memory: the masks of both passwords are
+// combined and applied in one step.
+//
+// The old password cannot be verified, so a wrong one results in a seed
+// that decrypts to a different secret. Returns ErrNotEncrypted if the seed
+// is not encrypted and ErrEmptyPassword if the new password is empty.
+func (s *Seed) ChangePassword(oldPassword, newPassword string) error {
+	if !s.IsEncrypted() {
+		return ErrNotEncrypted
+	}
+	newNorm := utf8NFKD(newPassword)
+	if strings.TrimSpace(newNorm) == "" {
+		return ErrEmptyPassword
+	}
+
+	oldMask := cryptMask(utf8NFKD(oldPassword), defaultKDF)
+	newMask := cryptMask(newNorm, defaultKDF)
+
+	for i := 0; i < internal.SecretSize; i++ {
+		s.secret[i] ^= oldMask[i] ^ newMask[i]
+	}
+	s.secret[internal.SecretSize-1] &= internal.ClearMask
+	s.updateChecksum()
+
+	memzero(oldMask)
+	memzero(newMask)
+	return nil
 }
 
 // IsEncrypted determines if the seed contents are encrypted
@@ -492,6 +1078,24 @@ func (s *Seed) IsEncrypted() bool {
 	return isEncrypted(s.features)
 }
 
+// EncryptionScheme inspects the internal feature bits of the seed and
+// returns its encryption scheme, EncryptionNone, EncryptionPassword or
+// EncryptionUnknown. encrypted is true unless the scheme is EncryptionNone.
+//
+// IsEncrypted only checks the passphrase bit. A wallet should refuse seeds
+// with EncryptionUnknown, because a future scheme may use the other
+// reserved bit, and Keygen would silently derive wrong keys from them.
+func (s *Seed) EncryptionScheme() (scheme int, encrypted bool) {
+	switch s.features & (FeatureMask &^ userFeaturesMask) {
+	case 0:
+		return EncryptionNone, false
+	case encryptedMask:
+		return EncryptionPassword, true
+	default:
+		return EncryptionUnknown, true
+	}
+}
+
 // Store serializes the seed data in a platform-independent way
 func (s *Seed) Store(storage *Storage) {
 	d := s.toData()
@@ -501,32 +1105,80 @@ func (s *Seed) Store(storage *Storage) {
 
 // Load deserializes a seed from storage format
 func Load(storage *Storage) (*Seed, error) {
-	d := &internal.Data{}
+	d, err := loadData(storage)
+	if err != nil {
+		return nil, err
+	}
+
+	seed := seedFromData(d)
+	putData(d)
+
+	return seed, nil
+}
+
+// loadData deserializes and verifies the seed data in storage. The caller
+// must release the returned data with putData.
+func loadData(storage *Storage) (*internal.Data, error) {
+	d := getData()
 	if err := internal.DataLoad((*[32]byte)(storage), d); err != nil {
-		if err == internal.StatusErrFormat {
+		putData(d)
+		if err == internal.StatusErrFormat || err == internal.StatusErrExtraByte {
 			return nil, StatusErrFormat
 		}
 		return nil, err
 	}
+	if err := verifyData(d); err != nil {
+		putData(d)
+		return nil, err
+	}
+
+	return d, nil
+}
 
+// LoadLenient deserializes a seed like Load, but also accepts storage from
+// implementations that do not write the extra byte after the secret, either
+// by writing another value in its place or by moving the footer into it.
+// It is meant for importing backups; use Load otherwise.
+//
+// The header, footer and checksum are still verified. Returns the error of
+// Load if the storage is invalid for another reason.
+func LoadLenient(storage *Storage) (*Seed, error) {
+	seed, err := Load(storage)
+	if err != StatusErrFormat {
+		return seed, err
+	}
+
+	d := getData()
+	defer putData(d)
+	if internal.DataLoad((*[32]byte)(storage), d) != internal.StatusErrExtraByte {
+		return nil, err
+	}
+	for _, layout := range []int{internal.LayoutAnyExtraByte, internal.LayoutNoExtraByte} {
+		if internal.DataLoadLenient((*[32]byte)(storage), d, layout) == nil && verifyData(d) == nil {
+			return seedFromData(d), nil
+		}
+	}
+
+	return nil, err
+}
+
+// verifyData checks the checksum and features of loaded seed data
+func verifyData(d *internal.Data) error {
 	// Verify checksum
 	p := &internal.GfPoly{}
+	defer wipePoly(p)
 	p.Coeff[0] = internal.GfElem(d.Checksum)
 	internal.DataToPoly(d, p)
 	if !p.Check() {
-		memzero(d.Secret[:])
-		return nil, StatusErrChecksum
+		return StatusErrChecksum
 	}
 
 	// Check features
 	if !featuresSupported(d.Features) {
-		memzero(d.Secret[:])
-		return nil, StatusErrUnsupported
+		return StatusErrUnsupported
 	}
 
-	seed := seedFromData(d)
-
-	return seed, nil
+	return nil
 }
 
 // GetNumLangs returns the number of supported languages
@@ -539,3 +1191,50 @@ func GetLang(i int) *lang.Language {
 	return lang.GetLang(i)
 }
 
+// LanguageCount returns the number of supported languages, including
+// registered custom languages
+func LanguageCount() int {
+	return lang.GetNumLangs()
+}
+
+// Languages returns the supported languages in index order. The slice is a
+// snapshot: it is not updated by later calls to lang.RegisterLanguage and
+// may be modified by the caller.
+func Languages() []*lang.Language {
+	langs := make([]*lang.Language, lang.GetNumLangs())
+	for i := range langs {
+		langs[i] = lang.GetLang(i)
+	}
+	return langs
+}
+
+// DetectLanguagePartial returns the languages that contain all of the given
+// words. It accepts an incomplete phrase, so the candidates narrow down as
+// more words are typed. With no words, all languages are returned.
+//
+// Returns an error if there are more than 16 words or no language matches.
+func DetectLanguagePartial(words []string) ([]*lang.Language, error) {
+	if len(words) > NumWords {
+		return nil, numWordsError(len(words), NumWords)
+	}
+
+	var candidates []*lang.Language
+	for i := 0; i < GetNumLangs(); i++ {
+		l := GetLang(i)
+		found := true
+		for _, word := range words {
+			if l.FindWord(UTF8NFKDLazy(word)) < 0 {
+				found = false
+				break
+			}
+		}
+		if found {
+			candidates = append(candidates, l)
+		}
+	}
+
+	if len(candidates) == 0 {
+		return nil, StatusErrLang
+	}
+	return candidates, nil
+}
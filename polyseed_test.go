@@ -4,7 +4,24 @@
 package polyseed
 
 import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"runtime"
+	"slices"
+	"strings"
+	"sync"
 	"testing"
+	"testing/iotest"
+	"time"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
 
 	"github.com/complex-gh/polyseed_go/internal"
 	"github.com/complex-gh/polyseed_go/lang"
@@ -89,6 +106,36 @@ const (
 	// Expected Spanish phrase with 4-char prefixes
 	expectedPhraseEs3 = "eje fin part cele tabu pest lien puma " +
 		"pris hora rega leng exis lapi lote sono"
+
+	// Expected French phrase for seed1
+	expectedPhraseFr1 = "parcelle sincère service golfeur figure animal peigne humble " +
+		"descente trombone réussir inoculer flocon orgueil admirer humide"
+
+	// Expected French phrase without accents
+	expectedPhraseFr2 = "parcelle sincere service golfeur figure animal peigne humble " +
+		"descente trombone reussir inoculer flocon orgueil admirer humide"
+
+	// Expected Chinese (Simplified) phrase for seed1
+	expectedPhraseZhS1 = "弧 悄 曼 居 械 由 渡 归 师 徽 漏 折 读 钠 下 召"
+
+	// Expected Portuguese phrase for seed1
+	expectedPhrasePt1 = "pacato servo segundo genial felino alugar panfleto honesto " +
+		"culatra tribo renovado inflamar fissura omitir adepto honrado"
+
+	// Expected Czech phrase for seed1
+	expectedPhraseCs1 = "ropovod usilovat ulita namazat matrika brunetka rozinka obarvit " +
+		"kadidlo vzlykat tabule odebrat milenec rakovina barbar obava"
+
+	// Expected Korean phrase for seed1
+	expectedPhraseKo1 = "잠깐 캠페인 친척 수컷 서점 고객 장식 신고 " +
+		"미용실 한마디 차림 아시아 설치 입대 강수량 신규"
+
+	// Expected Chinese (Traditional) phrase for seed1
+	expectedPhraseZhT1 = "弧 悄 曼 居 械 由 渡 歸 師 徽 漏 折 讀 鈉 下 召"
+
+	// Expected Italian phrase for seed2
+	expectedPhraseIt1 = "esistere gabbiano pupazzo cocco superbo reprimere milano rustico " +
+		"robusto labbro scheda mese flamenco mattone motosega srotolato"
 )
 
 // Specific random bytes that generate known seeds (from tests.c)
@@ -120,6 +167,18 @@ func getLangByName(name string) *lang.Language {
 	return nil
 }
 
+// newTestSeed creates the seed of expectedPhraseEn1, which is freed when
+// the test ends
+func newTestSeed(t *testing.T) *Seed {
+	t.Helper()
+	seed, err := CreateWithBirthday(randBytes1, seedTime1, 0)
+	if err != nil {
+		t.Fatalf("CreateWithBirthday failed: %v", err)
+	}
+	t.Cleanup(seed.Free)
+	return seed
+}
+
 // createSeedWithValues creates a seed with specific secret bytes, birthday timestamp, and features
 // This is a test helper function that allows deterministic seed creation
 func createSeedWithValues(secretBytes []byte, birthdayTimestamp uint64, features uint8) (*Seed, error) {
@@ -345,3 +404,3055 @@ func TestSeedPhraseGenerationWithSpecificValues(t *testing.T) {
 		}
 	})
 }
+
+func TestRecoverChecksumWord(t *testing.T) {
+	langEn := lang.GetLangByName("English")
+	words := strings.Fields(expectedPhraseEn1)
+
+	phrase, err := RecoverChecksumWord(words[:NumWords-1], CoinMonero, langEn)
+	if err != nil {
+		t.Fatalf("Failed to recover word: %v", err)
+	}
+	if phrase != expectedPhraseEn1 {
+		t.Errorf("Recovery failed:\nExpected: %q\nGot:      %q", expectedPhraseEn1, phrase)
+	}
+
+	if _, err := RecoverChecksumWord(words, CoinMonero, langEn); !errors.Is(err, StatusErrNumWords) {
+		t.Errorf("Expected StatusErrNumWords, got %v", err)
+	}
+
+	words[3] = "notaword"
+	if _, err := RecoverChecksumWord(words[:NumWords-1], CoinMonero, langEn); err != StatusErrLang {
+		t.Errorf("Expected StatusErrLang, got %v", err)
+	}
+}
+
+func TestCoefficientDistance(t *testing.T) {
+	dist, err := CoefficientDistance(expectedPhraseEn1, expectedPhraseEn1, CoinMonero)
+	if err != nil || dist != 0 {
+		t.Errorf("Expected distance 0, got %d (%v)", dist, err)
+	}
+
+	// Replace two words
+	words := strings.Fields(expectedPhraseEn1)
+	words[2] = "abandon"
+	words[9] = "zoo"
+	dist, err = CoefficientDistance(expectedPhraseEn1, strings.Join(words, " "), CoinMonero)
+	if err != nil || dist != 2 {
+		t.Errorf("Expected distance 2, got %d (%v)", dist, err)
+	}
+
+	// The same seed in another language has distance 0
+	seed, _, err := Decode(expectedPhraseEn1, CoinMonero)
+	if err != nil {
+		t.Fatalf("Failed to decode phrase: %v", err)
+	}
+	defer seed.Free()
+	phraseEs := seed.Encode(lang.GetLangByName("Spanish"), CoinMonero)
+	dist, err = CoefficientDistance(expectedPhraseEn1, phraseEs, CoinMonero)
+	if err != nil || dist != 0 {
+		t.Errorf("Expected distance 0 across languages, got %d (%v)", dist, err)
+	}
+
+	if _, err := CoefficientDistance(expectedPhraseEn1, "raven tail", CoinMonero); !errors.Is(err, StatusErrNumWords) {
+		t.Errorf("Expected StatusErrNumWords, got %v", err)
+	}
+}
+
+func TestCryptRejectsEmptyPassword(t *testing.T) {
+	seed, err := createSeedWithValues(randBytes1, seedTime1, 0)
+	if err != nil {
+		t.Fatalf("Failed to create seed: %v", err)
+	}
+	defer seed.Free()
+
+	for _, password := range []string{"", "   ", "　"} {
+		if err := seed.Crypt(password); err != ErrEmptyPassword {
+			t.Errorf("Crypt(%q): expected ErrEmptyPassword, got %v", password, err)
+		}
+		if seed.IsEncrypted() {
+			t.Fatalf("Crypt(%q) encrypted the seed", password)
+		}
+	}
+
+	// Explicitly allowed empty password
+	seed.CryptAllowEmpty("")
+	if !seed.IsEncrypted() {
+		t.Error("Expected seed to be encrypted")
+	}
+	seed.CryptAllowEmpty("")
+	if phrase := seed.Encode(lang.GetLangByName("English"), CoinMonero); phrase != expectedPhraseEn1 {
+		t.Errorf("Decryption failed:\nExpected: %q\nGot:      %q", expectedPhraseEn1, phrase)
+	}
+
+	if err := seed.Crypt("password"); err != nil || !seed.IsEncrypted() {
+		t.Errorf("Expected seed to be encrypted, got %v", err)
+	}
+}
+
+func TestFeatureMismatch(t *testing.T) {
+	EnableFeatures(5)
+	defer EnableFeatures(0)
+
+	seed, err := createSeedWithValues(randBytes1, seedTime1, 5)
+	if err != nil {
+		t.Fatalf("Failed to create seed: %v", err)
+	}
+	defer seed.Free()
+	phrase := seed.Encode(lang.GetLangByName("English"), CoinMonero)
+
+	// Only feature 1 is enabled at the decode site
+	EnableFeatures(1)
+	_, _, err = Decode(phrase, CoinMonero)
+	if err != StatusErrUnsupported {
+		t.Fatalf("Expected StatusErrUnsupported, got %v", err)
+	}
+
+	missing, ok := FeatureMismatch(err, phrase, CoinMonero)
+	if !ok || missing != 4 {
+		t.Errorf("Expected missing feature 4, got %d (ok=%v)", missing, ok)
+	}
+
+	if _, ok := FeatureMismatch(StatusErrChecksum, phrase, CoinMonero); ok {
+		t.Error("Expected no mismatch for unrelated error")
+	}
+}
+
+func TestCreateVanity(t *testing.T) {
+	langEn := lang.GetLangByName("English")
+
+	seed, err := CreateVanity(0, "zoo", langEn, 100000)
+	if err != nil {
+		t.Fatalf("Failed to create vanity seed: %v", err)
+	}
+	defer seed.Free()
+
+	phrase := seed.Encode(langEn, CoinMonero)
+	if words := strings.Fields(phrase); words[0] != "zoo" {
+		t.Errorf("Expected checksum word \"zoo\", got %q", words[0])
+	}
+
+	if _, err := CreateVanity(0, "notaword", langEn, 1); err != StatusErrLang {
+		t.Errorf("Expected StatusErrLang, got %v", err)
+	}
+	if _, err := CreateVanity(0, "zoo", langEn, 0); err != ErrVanityNotFound {
+		t.Errorf("Expected ErrVanityNotFound, got %v", err)
+	}
+}
+
+func TestPackMetaRoundtrip(t *testing.T) {
+	for features := uint8(0); features <= internal.FeatureMask; features++ {
+		for birthday := uint16(0); birthday <= internal.DateMask; birthday++ {
+			v := internal.PackMeta(features, birthday)
+			f, b := internal.UnpackMeta(v)
+			if f != features || b != birthday {
+				t.Fatalf("Roundtrip failed for (%d, %d): got (%d, %d)", features, birthday, f, b)
+			}
+		}
+	}
+}
+
+func TestSnapshotConcurrentReads(t *testing.T) {
+	seed, err := createSeedWithValues(randBytes1, seedTime1, 0)
+	if err != nil {
+		t.Fatalf("Failed to create seed: %v", err)
+	}
+	snapshot := seed.Snapshot()
+	defer snapshot.Free()
+
+	// Wiping the original must not affect the snapshot
+	seed.Free()
+
+	langEn := lang.GetLangByName("English")
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if phrase := snapshot.Encode(langEn, CoinMonero); phrase != expectedPhraseEn1 {
+				t.Errorf("Unexpected phrase: %q", phrase)
+			}
+			if birthday := snapshot.GetBirthday(); birthday != 1638397746 {
+				t.Errorf("Unexpected birthday: %d", birthday)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestKeygenPath(t *testing.T) {
+	seed, err := createSeedWithValues(randBytes1, seedTime1, 0)
+	if err != nil {
+		t.Fatalf("Failed to create seed: %v", err)
+	}
+	defer seed.Free()
+
+	vectors := []struct {
+		path string
+		key  string
+	}{
+		{"0", "1ef7b415064cd28618612835d5c41cd159f11dbef05c2650c9149bc6d267d459"},
+		{"0/1", "644497b171a2961dd547f7397611d0fa3cf0e56227bdead037698ad769ff6096"},
+		{"44/128/0", "cb86caf3453df895cc5c3557be91424afdabf116692a5a57d2f4748247edc70e"},
+	}
+	for _, v := range vectors {
+		key, err := seed.KeygenPath(CoinMonero, v.path, 32)
+		if err != nil {
+			t.Fatalf("KeygenPath(%q) failed: %v", v.path, err)
+		}
+		if hex.EncodeToString(key) != v.key {
+			t.Errorf("KeygenPath(%q) = %x, expected %s", v.path, key, v.key)
+		}
+	}
+
+	// An empty path is the same as Keygen
+	key, err := seed.KeygenPath(CoinMonero, "", 32)
+	if err != nil || !bytes.Equal(key, seed.Keygen(CoinMonero, 32)) {
+		t.Errorf("Empty path does not match Keygen (%v)", err)
+	}
+
+	for _, path := range []string{"/", "0/", "-1", "a/b", "4294967296", "+1"} {
+		if _, err := seed.KeygenPath(CoinMonero, path, 32); err != ErrPath {
+			t.Errorf("KeygenPath(%q): expected ErrPath, got %v", path, err)
+		}
+	}
+}
+
+func TestKeygenAccount(t *testing.T) {
+	seed := newTestSeed(t)
+
+	// Account 0 is the same as Keygen
+	if key := seed.KeygenAccount(CoinMonero, 0, 32); !bytes.Equal(key, seed.Keygen(CoinMonero, 32)) {
+		t.Error("Account 0 does not match Keygen")
+	}
+
+	vectors := []struct {
+		account uint32
+		key     string
+	}{
+		{1, "67953c5a7696df778a5ff2c6bbc443f73d538b16d642f4573dcff106e437a857"},
+		{0x12345678, "43d74b82553a811c88386533c1fc7e278282d6597f4709c1b65f55e40fb52d6e"},
+	}
+	for _, v := range vectors {
+		key := seed.KeygenAccount(CoinMonero, v.account, 32)
+		if hex.EncodeToString(key) != v.key {
+			t.Errorf("KeygenAccount(%d) = %x, expected %s", v.account, key, v.key)
+		}
+	}
+}
+
+func TestKeygenMulti(t *testing.T) {
+	seed := newTestSeed(t)
+
+	keys, err := seed.KeygenMulti(CoinMonero, 32, 16, 32)
+	if err != nil || len(keys) != 3 {
+		t.Fatalf("KeygenMulti returned %d keys, %v; expected 3", len(keys), err)
+	}
+	expected := []string{
+		"6bf5a95b037b7f6d87e02eb079707fbf6ed820705ba6970a96ed6b1c6c097fea",
+		"f949fc9857e0449a658179435ad4a8dd",
+		"b1555393e75d0c9913006966994521817fb1aa769971506561296d191dec028d",
+	}
+	for i, key := range keys {
+		if hex.EncodeToString(key) != expected[i] {
+			t.Errorf("key %d = %x, expected %s", i, key, expected[i])
+		}
+	}
+
+	// Keys do not share memory
+	keys[0].Zero()
+	if hex.EncodeToString(keys[1]) != expected[1] {
+		t.Error("wiping a key modified the next key")
+	}
+
+	if keys, err := seed.KeygenMulti(CoinMonero); err != nil || len(keys) != 0 {
+		t.Errorf("KeygenMulti without sizes returned %d keys, %v", len(keys), err)
+	}
+	for _, sizes := range [][]int{{32, -1}, {8161}} {
+		if keys, err := seed.KeygenMulti(CoinMonero, sizes...); err != ErrKeySize || keys != nil {
+			t.Errorf("KeygenMulti(%v): expected ErrKeySize, got %v", sizes, err)
+		}
+	}
+}
+
+func TestKeygenCached(t *testing.T) {
+	seed := newTestSeed(t)
+
+	expected := seed.Keygen(CoinMonero, 32)
+	var wg sync.WaitGroup
+	for range 4 {
+		wg.Go(func() {
+			if key := seed.KeygenCached(CoinMonero, 32); !bytes.Equal(key, expected) {
+				t.Errorf("KeygenCached = %x, expected %x", key, expected)
+			}
+		})
+	}
+	wg.Wait()
+
+	key := seed.KeygenCached(CoinMonero, 32)
+	key[0] ^= 0xFF
+	if again := seed.KeygenCached(CoinMonero, 32); !bytes.Equal(again, expected) {
+		t.Error("modifying a returned key changed the cache")
+	}
+	if key := seed.KeygenCached(CoinAeon, 64); !bytes.Equal(key, seed.Keygen(CoinAeon, 64)) {
+		t.Error("KeygenCached mismatch for Aeon")
+	}
+	if len(seed.keys) != 2 {
+		t.Errorf("expected 2 cached keys, got %d", len(seed.keys))
+	}
+	if clone := seed.Clone(); clone.keys != nil {
+		t.Error("clone shares the key cache")
+	}
+
+	// Changing the seed drops the cached keys
+	cached := seed.keys[keyCacheKey{CoinMonero, 32}]
+	if err := seed.Crypt("password"); err != nil {
+		t.Fatalf("Crypt failed: %v", err)
+	}
+	if seed.keys != nil || !bytes.Equal(cached, make([]byte, 32)) {
+		t.Error("Crypt did not wipe the key cache")
+	}
+	if key := seed.KeygenCached(CoinMonero, 32); !bytes.Equal(key, seed.Keygen(CoinMonero, 32)) || bytes.Equal(key, expected) {
+		t.Error("KeygenCached returned a stale key after Crypt")
+	}
+
+	cached = seed.keys[keyCacheKey{CoinMonero, 32}]
+	seed.Free()
+	if seed.keys != nil || !bytes.Equal(cached, make([]byte, 32)) {
+		t.Error("Free did not wipe the key cache")
+	}
+}
+
+func TestKeygenContext(t *testing.T) {
+	seed := newTestSeed(t)
+
+	key, err := seed.KeygenContext(context.Background(), CoinMonero, 32)
+	if err != nil || !bytes.Equal(key, seed.Keygen(CoinMonero, 32)) {
+		t.Errorf("KeygenContext = %x, %v, expected the Keygen key", key, err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if key, err := seed.KeygenContext(ctx, CoinMonero, 32); key != nil || !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %x, %v", key, err)
+	}
+	if err := seed.CryptContext(ctx, "password"); !errors.Is(err, context.Canceled) || seed.IsEncrypted() {
+		t.Errorf("expected context.Canceled and an unchanged seed, got %v", err)
+	}
+
+	// Cancellation during the rounds
+	ctx, cancel = context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if _, err := pbkdf2SHA256Context(ctx, []byte("password"), []byte("salt"), 1<<40, 32); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected context.DeadlineExceeded, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("cancellation took %v", elapsed)
+	}
+
+	if key, err := pbkdf2SHA256Context(context.Background(), []byte("password"), []byte("salt"), 1, -1); key != nil || err != ErrKeySize {
+		t.Errorf("expected ErrKeySize for a negative key length, got %x, %v", key, err)
+	}
+
+	for _, keyLen := range []int{1, 32, 33, 64, 100} {
+		for _, iterations := range []int{1, 2, 999, 1000, 1001} {
+			expected := pbkdf2SHA256([]byte("password"), []byte("salt"), iterations, keyLen)
+			key, err := pbkdf2SHA256Context(context.Background(), []byte("password"), []byte("salt"), iterations, keyLen)
+			if err != nil || !bytes.Equal(key, expected) {
+				t.Errorf("pbkdf2SHA256Context(%d, %d) = %x, %v, expected %x", iterations, keyLen, key, err, expected)
+			}
+		}
+	}
+
+	encrypted := seed.Clone()
+	defer encrypted.Free()
+	if err := encrypted.Crypt("password"); err != nil {
+		t.Fatalf("Crypt failed: %v", err)
+	}
+	if err := seed.CryptContext(context.Background(), "password"); err != nil || !seed.Equal(encrypted) {
+		t.Errorf("CryptContext does not match Crypt: %v", err)
+	}
+}
+
+func TestEncodeIndices(t *testing.T) {
+	seed := newTestSeed(t)
+
+	for _, coin := range []Coin{CoinMonero, CoinAeon} {
+		indices, err := seed.EncodeIndices(coin)
+		if err != nil {
+			t.Fatalf("EncodeIndices(%v) failed: %v", coin, err)
+		}
+		for _, l := range []*lang.Language{lang.GetLangByName("English"), lang.GetLangByName("Japanese")} {
+			words := make([]string, NumWords)
+			for i, idx := range indices {
+				words[i] = l.Words[idx]
+			}
+			phrase := strings.Join(words, l.Separator)
+			if l.Compose {
+				phrase = norm.NFC.String(phrase)
+			}
+			if expected := seed.Encode(l, coin); phrase != expected {
+				t.Errorf("%s/%v: indices give %q, expected %q", l.GetLangNameEn(), coin, phrase, expected)
+			}
+		}
+	}
+
+	if indices, err := seed.EncodeIndices(internal.GfSize); err != StatusErrUnsupported || indices != [NumWords]uint16{} {
+		t.Errorf("EncodeIndices: expected StatusErrUnsupported, got %v, %v", indices, err)
+	}
+}
+
+func TestDecodeWords(t *testing.T) {
+	expected, _, err := Decode(expectedPhraseEn1, CoinMonero)
+	if err != nil {
+		t.Fatalf("Decode failed: %v", err)
+	}
+	defer expected.Free()
+
+	words := strings.Fields(expectedPhraseEn1)
+	words[0] = "  " + words[0] + "\t"
+	seed, foundLang, err := DecodeWords(words, CoinMonero)
+	if err != nil {
+		t.Fatalf("DecodeWords failed: %v", err)
+	}
+	defer seed.Free()
+	if !seed.Equal(expected) || foundLang.GetLangNameEn() != "English" {
+		t.Errorf("DecodeWords mismatch, language %s", foundLang.GetLangNameEn())
+	}
+
+	// Composed accents in separate words
+	expectedEs, _, err := Decode(expectedPhraseEs1, CoinMonero)
+	if err != nil {
+		t.Fatalf("Decode failed: %v", err)
+	}
+	defer expectedEs.Free()
+	seed, foundLang, err = DecodeWords(strings.Fields(norm.NFC.String(expectedPhraseEs1)), CoinMonero)
+	if err != nil {
+		t.Fatalf("DecodeWords failed for Spanish: %v", err)
+	}
+	defer seed.Free()
+	if !seed.Equal(expectedEs) || foundLang.GetLangNameEn() != "Spanish" {
+		t.Errorf("DecodeWords mismatch for Spanish, language %s", foundLang.GetLangNameEn())
+	}
+
+	var numErr *NumWordsError
+	if _, _, err := DecodeWords(words[:15], CoinMonero); !errors.As(err, &numErr) || numErr.Got != 15 {
+		t.Errorf("Expected a NumWordsError, got %v", err)
+	}
+	if _, _, err := DecodeWords([]string{"raventail"}, CoinMonero); !errors.Is(err, StatusErrNumWords) {
+		t.Errorf("Expected StatusErrNumWords, got %v", err)
+	}
+	words[3] = "xyzzy"
+	if _, _, err := DecodeWords(words, CoinMonero); err != StatusErrLang {
+		t.Errorf("Expected StatusErrLang, got %v", err)
+	}
+}
+
+func TestSecretBytes(t *testing.T) {
+	seed := newTestSeed(t)
+
+	key := seed.Keygen(CoinMonero, 32)
+	var plain []byte = key
+	if hex.EncodeToString(key.Bytes()) != TestVectors()[0].Key || &plain[0] != &key.Bytes()[0] {
+		t.Errorf("unexpected key %x", key)
+	}
+
+	account := seed.KeygenAccount(CoinMonero, 1, 32)
+	account.Zero()
+	key.Zero()
+	if !bytes.Equal(plain, make([]byte, 32)) || !bytes.Equal(account, make([]byte, 32)) {
+		t.Error("Zero did not wipe the key")
+	}
+}
+
+func TestBirthdayLabel(t *testing.T) {
+	seed := newTestSeed(t)
+
+	if label := seed.BirthdayLabel(); label != "December 2021" {
+		t.Errorf("BirthdayLabel = %q, expected December 2021", label)
+	}
+
+	// Every birthday is labeled with its own month
+	month := time.Date(2021, time.November, 1, 0, 0, 0, 0, time.UTC)
+	for b := uint16(0); b <= DateMask; b++ {
+		seed.birthday = b
+		if label, expected := seed.BirthdayLabel(), month.AddDate(0, int(b), 0).Format("January 2006"); label != expected {
+			t.Fatalf("birthday %d: label %q, expected %q", b, label, expected)
+		}
+	}
+}
+
+func TestLoadLenient(t *testing.T) {
+	seed := newTestSeed(t)
+	var storage Storage
+	seed.Store(&storage)
+
+	anyExtra := storage
+	anyExtra[29] = 0
+	noExtra := storage
+	copy(noExtra[29:], storage[30:])
+	noExtra[31] = 0
+
+	for name, st := range map[string]Storage{"strict": storage, "any extra byte": anyExtra, "no extra byte": noExtra} {
+		loaded, err := LoadLenient(&st)
+		if err != nil {
+			t.Errorf("%s: LoadLenient failed: %v", name, err)
+			continue
+		}
+		if !loaded.Equal(seed) {
+			t.Errorf("%s: loaded seed differs", name)
+		}
+		loaded.Free()
+	}
+	if _, err := Load(&anyExtra); err != StatusErrFormat {
+		t.Errorf("Load accepted a wrong extra byte: %v", err)
+	}
+
+	// The checksum, header and footer are still verified
+	badChecksum := noExtra
+	badChecksum[29] ^= 1
+	badHeader := anyExtra
+	badHeader[0] = 'X'
+	badFooter := anyExtra
+	badFooter[31] = 0
+	for name, st := range map[string]Storage{"checksum": badChecksum, "header": badHeader, "footer": badFooter} {
+		if _, err := LoadLenient(&st); err != StatusErrFormat {
+			t.Errorf("%s: expected StatusErrFormat, got %v", name, err)
+		}
+	}
+}
+
+func TestDetectLanguagePartial(t *testing.T) {
+	candidates, err := DetectLanguagePartial(nil)
+	if err != nil || len(candidates) != GetNumLangs() {
+		t.Errorf("Expected all languages, got %d (%v)", len(candidates), err)
+	}
+
+	words := strings.Fields(expectedPhraseEs2)
+	candidates, err = DetectLanguagePartial(words[:4])
+	if err != nil || len(candidates) != 1 || candidates[0] != lang.GetLangByName("Spanish") {
+		t.Errorf("Expected Spanish, got %v (%v)", candidates, err)
+	}
+
+	if _, err := DetectLanguagePartial([]string{"raven", "célebre"}); err != StatusErrLang {
+		t.Errorf("Expected StatusErrLang, got %v", err)
+	}
+
+	var numErr *NumWordsError
+	_, err = DetectLanguagePartial(append(words, "raven"))
+	if !errors.As(err, &numErr) || numErr.Got != NumWords+1 || numErr.Expected != NumWords {
+		t.Errorf("Expected a NumWordsError for 17 words, got %v", err)
+	}
+}
+
+func TestCreateFromEntropy(t *testing.T) {
+	seed, err := CreateFromEntropy(randBytes1, 0)
+	if err != nil {
+		t.Fatalf("Failed to create seed: %v", err)
+	}
+	defer seed.Free()
+
+	// The birthday is the current time, so only the secret is compared
+	reference, err := createSeedWithValues(randBytes1, seedTime1, 0)
+	if err != nil {
+		t.Fatalf("Failed to create seed: %v", err)
+	}
+	defer reference.Free()
+	if seed.secret != reference.secret {
+		t.Error("Secret mismatch")
+	}
+
+	if _, err := CreateFromEntropy(randBytes1[:18], 0); err != StatusErrFormat {
+		t.Errorf("Expected StatusErrFormat, got %v", err)
+	}
+	if _, err := CreateFromEntropy(append(randBytes1, 0), 0); err != StatusErrFormat {
+		t.Errorf("Expected StatusErrFormat, got %v", err)
+	}
+	if _, err := CreateFromEntropy(randBytes1, 1); err != StatusErrUnsupported {
+		t.Errorf("Expected StatusErrUnsupported, got %v", err)
+	}
+}
+
+func TestCreateWithBirthday(t *testing.T) {
+	for _, timestamp := range []uint64{0, epoch - 1, ^uint64(0)} {
+		seed, err := CreateWithBirthday(randBytes1, timestamp, 0)
+		if err != nil {
+			t.Fatalf("Failed to create seed: %v", err)
+		}
+		if birthday := seed.GetBirthday(); birthday != epoch {
+			t.Errorf("Timestamp %d: expected birthday %d, got %d", timestamp, epoch, birthday)
+		}
+		seed.Free()
+	}
+
+	if _, err := CreateWithBirthday(randBytes1[:10], seedTime1, 0); err != StatusErrFormat {
+		t.Errorf("Expected StatusErrFormat, got %v", err)
+	}
+}
+
+func TestCreateWithReader(t *testing.T) {
+	seed, err := CreateWithReader(bytes.NewReader(randBytes1), 0)
+	if err != nil {
+		t.Fatalf("Failed to create seed: %v", err)
+	}
+	defer seed.Free()
+	if !bytes.Equal(seed.secret[:internal.SecretSize-1], randBytes1[:internal.SecretSize-1]) {
+		t.Error("Secret was not read from the reader")
+	}
+
+	if _, err := CreateWithReader(bytes.NewReader(randBytes1[:5]), 0); err != StatusErrMemory {
+		t.Errorf("Expected StatusErrMemory on short read, got %v", err)
+	}
+}
+
+func TestSecret(t *testing.T) {
+	seed := newTestSeed(t)
+
+	secret := seed.Secret()
+	if !bytes.Equal(secret, seed.secret[:internal.SecretSize]) {
+		t.Errorf("Secret mismatch: %x", secret)
+	}
+
+	// Modifying the copy must not affect the seed
+	memzero(secret)
+	if phrase := seed.Encode(lang.GetLangByName("English"), CoinMonero); phrase != expectedPhraseEn1 {
+		t.Errorf("Seed was modified through the copy: %q", phrase)
+	}
+}
+
+func TestClone(t *testing.T) {
+	seed := newTestSeed(t)
+	clone := seed.Clone()
+	defer clone.Free()
+
+	seed.Free()
+
+	if phrase := clone.Encode(lang.GetLangByName("English"), CoinMonero); phrase != expectedPhraseEn1 {
+		t.Errorf("Clone was affected by Free:\nExpected: %q\nGot:      %q", expectedPhraseEn1, phrase)
+	}
+}
+
+func TestEqual(t *testing.T) {
+	seed := newTestSeed(t)
+
+	decoded, _, err := Decode(expectedPhraseEn1, CoinMonero)
+	if err != nil {
+		t.Fatalf("Failed to decode phrase: %v", err)
+	}
+	defer decoded.Free()
+	if !seed.Equal(decoded) {
+		t.Error("Expected decoded seed to equal the original")
+	}
+
+	other, err := CreateWithBirthday(randBytes2, seedTime1, 0)
+	if err != nil {
+		t.Fatalf("Failed to create seed: %v", err)
+	}
+	defer other.Free()
+	if seed.Equal(other) || seed.Equal(nil) {
+		t.Error("Expected seeds to differ")
+	}
+}
+
+func TestBinaryMarshaling(t *testing.T) {
+	seed := newTestSeed(t)
+
+	data, err := seed.MarshalBinary()
+	if err != nil || len(data) != StorageSize {
+		t.Fatalf("MarshalBinary failed: %d bytes, %v", len(data), err)
+	}
+
+	var loaded Seed
+	if err := loaded.UnmarshalBinary(data); err != nil {
+		t.Fatalf("UnmarshalBinary failed: %v", err)
+	}
+	defer loaded.Free()
+	if !seed.Equal(&loaded) {
+		t.Error("Roundtrip mismatch")
+	}
+
+	if err := loaded.UnmarshalBinary(data[1:]); err != StatusErrFormat {
+		t.Errorf("Expected StatusErrFormat, got %v", err)
+	}
+	data[StorageSize-1] ^= 0x01
+	if err := loaded.UnmarshalBinary(data); err != StatusErrFormat && err != StatusErrChecksum {
+		t.Errorf("Expected an error for corrupted data, got %v", err)
+	}
+}
+
+func TestJSONMarshaling(t *testing.T) {
+	seed := newTestSeed(t)
+
+	data, err := json.Marshal(seed)
+	if err != nil {
+		t.Fatalf("MarshalJSON failed: %v", err)
+	}
+	if !strings.Contains(string(data), `"birthday_time":"2021-12-01T22:29:06Z"`) {
+		t.Errorf("Unexpected JSON: %s", data)
+	}
+
+	var loaded Seed
+	if err := json.Unmarshal(data, &loaded); err != nil {
+		t.Fatalf("UnmarshalJSON failed: %v", err)
+	}
+	defer loaded.Free()
+	if !seed.Equal(&loaded) {
+		t.Error("Roundtrip mismatch")
+	}
+
+	for _, input := range []string{`{}`, `{"storage":"zz"}`, `{"storage":"00"}`} {
+		if err := json.Unmarshal([]byte(input), &loaded); err != StatusErrFormat {
+			t.Errorf("%s: expected StatusErrFormat, got %v", input, err)
+		}
+	}
+}
+
+func TestGetLangByName(t *testing.T) {
+	for _, name := range []string{"English", "english", "ENGLISH"} {
+		if l := lang.GetLangByName(name); l != GetLang(0) {
+			t.Errorf("GetLangByName(%q) = %v", name, l)
+		}
+	}
+	if l := lang.GetLangByName("Klingon"); l != nil {
+		t.Errorf("Expected nil, got %v", l)
+	}
+}
+
+func TestGetLangByNativeName(t *testing.T) {
+	langEs := lang.GetLangByName("Spanish")
+	for _, name := range []string{"español", "espan\u0303ol"} {
+		if l := lang.GetLangByNativeName(name); l != langEs {
+			t.Errorf("GetLangByNativeName(%q) = %v", name, l)
+		}
+	}
+	if l := lang.GetLangByNativeName("日本語"); l != lang.GetLangByName("Japanese") {
+		t.Errorf("Expected Japanese, got %v", l)
+	}
+}
+
+func TestGetLangByCode(t *testing.T) {
+	bundled := []*lang.Language{
+		&lang.LangEn, &lang.LangJp, &lang.LangKo, &lang.LangEs, &lang.LangFr,
+		&lang.LangIt, &lang.LangCs, &lang.LangPt, &lang.LangZhS, &lang.LangZhT,
+	}
+	codes := make(map[string]bool)
+	for _, l := range bundled {
+		if l.Code == "" {
+			t.Errorf("%s has no code", l.NameEn)
+			continue
+		}
+		if codes[strings.ToLower(l.Code)] {
+			t.Errorf("%s: duplicate code %q", l.NameEn, l.Code)
+		}
+		codes[strings.ToLower(l.Code)] = true
+		if found := lang.GetLangByCode(l.Code); found != l {
+			t.Errorf("GetLangByCode(%q) = %v", l.Code, found)
+		}
+	}
+
+	tests := []struct {
+		code   string
+		nameEn string
+	}{
+		{"EN", "English"},
+		{"en-US", "English"},
+		{"pt_BR", "Portuguese"},
+		{"zh-hant", "Chinese (Traditional)"},
+		{"zh_Hans_CN", "Chinese (Simplified)"},
+		{"ja-JP", "Japanese"},
+	}
+	for _, tt := range tests {
+		if found := lang.GetLangByCode(tt.code); found != lang.GetLangByName(tt.nameEn) {
+			t.Errorf("GetLangByCode(%q) = %v, expected %s", tt.code, found, tt.nameEn)
+		}
+	}
+	for _, code := range []string{"", "xx", "zh", "-"} {
+		if found := lang.GetLangByCode(code); found != nil {
+			t.Errorf("GetLangByCode(%q) = %v, expected nil", code, found)
+		}
+	}
+
+	duplicate := lang.LangEn
+	duplicate.NameEn = "Duplicate code"
+	if err := lang.RegisterLanguage(&duplicate); !errors.Is(err, lang.ErrWordlist) {
+		t.Errorf("Expected ErrWordlist for duplicate code, got %v", err)
+	}
+}
+
+func TestDecodeJapaneseSeparators(t *testing.T) {
+	langJp := lang.GetLangByName("Japanese")
+	if langJp.Separator != "　" || !langJp.Compose || langJp.HasAccents || langJp.HasPrefix {
+		t.Fatalf("Unexpected Japanese language flags: %+v", langJp)
+	}
+
+	seed := newTestSeed(t)
+
+	phrase := seed.Encode(langJp, CoinMonero)
+	variants := map[string]string{
+		"IdeographicSpace": phrase,
+		"Space":            strings.ReplaceAll(phrase, "　", " "),
+		"NoSeparator":      strings.ReplaceAll(phrase, "　", ""),
+	}
+	for name, variant := range variants {
+		t.Run(name, func(t *testing.T) {
+			decoded, decodedLang, err := Decode(variant, CoinMonero)
+			if err != nil {
+				t.Fatalf("Failed to decode phrase: %v", err)
+			}
+			defer decoded.Free()
+			if decodedLang != langJp || !decoded.Equal(seed) {
+				t.Error("Decoded seed mismatch")
+			}
+
+			explicit, err := DecodeExplicit(variant, CoinMonero, langJp)
+			if err != nil {
+				t.Fatalf("Failed to decode phrase explicitly: %v", err)
+			}
+			defer explicit.Free()
+			if !explicit.Equal(seed) {
+				t.Error("Explicitly decoded seed mismatch")
+			}
+		})
+	}
+
+	if _, _, err := Decode("ravenTail", CoinMonero); !errors.Is(err, StatusErrNumWords) {
+		t.Errorf("Expected StatusErrNumWords, got %v", err)
+	}
+}
+
+func TestFrenchVector(t *testing.T) {
+	langFr := lang.GetLangByName("French")
+	if !langFr.HasAccents || !langFr.HasPrefix || !langFr.Compose {
+		t.Fatalf("Unexpected French language flags: %+v", langFr)
+	}
+
+	seed := newTestSeed(t)
+
+	if phrase := seed.Encode(langFr, CoinMonero); phrase != expectedPhraseFr1 {
+		t.Errorf("Encoding failed:\nExpected: %q\nGot:      %q", expectedPhraseFr1, phrase)
+	}
+
+	for _, phrase := range []string{expectedPhraseFr1, expectedPhraseFr2} {
+		decoded, decodedLang, err := Decode(phrase, CoinMonero)
+		if err != nil {
+			t.Fatalf("Failed to decode %q: %v", phrase, err)
+		}
+		if decodedLang != langFr || !decoded.Equal(seed) {
+			t.Errorf("Decoded seed mismatch for %q", phrase)
+		}
+		decoded.Free()
+	}
+}
+
+func TestPortugueseVector(t *testing.T) {
+	langPt := lang.GetLangByName("Portuguese")
+
+	// The polyseed Portuguese wordlist is plain ASCII, so it needs no
+	// accent folding
+	if langPt.HasAccents || !langPt.HasPrefix || langPt.Compose {
+		t.Fatalf("Unexpected Portuguese language flags: %+v", langPt)
+	}
+	for i, word := range langPt.Words {
+		for _, r := range word {
+			if r < 'a' || r > 'z' {
+				t.Fatalf("Word %d %q is not lowercase ASCII", i, word)
+			}
+		}
+	}
+
+	seed := newTestSeed(t)
+	if phrase := seed.Encode(langPt, CoinMonero); phrase != expectedPhrasePt1 {
+		t.Errorf("Encoding failed:\nExpected: %q\nGot:      %q", expectedPhrasePt1, phrase)
+	}
+
+	// Decodes in full and with 4-character prefixes
+	var prefixes []string
+	for _, word := range strings.Fields(expectedPhrasePt1) {
+		prefixes = append(prefixes, word[:min(len(word), 4)])
+	}
+	for _, phrase := range []string{expectedPhrasePt1, strings.Join(prefixes, " ")} {
+		decoded, decodedLang, err := Decode(phrase, CoinMonero)
+		if err != nil {
+			t.Fatalf("Failed to decode %q: %v", phrase, err)
+		}
+		if decodedLang != langPt || !decoded.Equal(seed) {
+			t.Errorf("Decoded seed mismatch for %q", phrase)
+		}
+		decoded.Free()
+	}
+}
+
+func TestCzechVector(t *testing.T) {
+	langCs := lang.GetLangByName("Czech")
+
+	// The polyseed Czech wordlist is written without diacritics
+	if langCs.HasAccents || !langCs.HasPrefix || langCs.Compose {
+		t.Fatalf("Unexpected Czech language flags: %+v", langCs)
+	}
+	for i, word := range langCs.Words {
+		for _, r := range word {
+			if r < 'a' || r > 'z' {
+				t.Fatalf("Word %d %q is not lowercase ASCII", i, word)
+			}
+		}
+	}
+
+	seed := newTestSeed(t)
+	if phrase := seed.Encode(langCs, CoinMonero); phrase != expectedPhraseCs1 {
+		t.Errorf("Encoding failed:\nExpected: %q\nGot:      %q", expectedPhraseCs1, phrase)
+	}
+
+	// Decodes in full, with 4-character prefixes and with the words
+	// truncated anywhere in between
+	var prefixes, mixed []string
+	for i, word := range strings.Fields(expectedPhraseCs1) {
+		prefixes = append(prefixes, word[:4])
+		mixed = append(mixed, word[:4+i%(len(word)-3)])
+	}
+	for _, phrase := range []string{expectedPhraseCs1, strings.Join(prefixes, " "), strings.Join(mixed, " ")} {
+		decoded, decodedLang, err := Decode(phrase, CoinMonero)
+		if err != nil {
+			t.Fatalf("Failed to decode %q: %v", phrase, err)
+		}
+		if decodedLang != langCs || !decoded.Equal(seed) {
+			t.Errorf("Decoded seed mismatch for %q", phrase)
+		}
+		decoded.Free()
+	}
+}
+
+func TestItalianVector(t *testing.T) {
+	langIt := lang.GetLangByName("Italian")
+
+	// The Italian wordlist contains no accented words, so there is no entry
+	// such as "perché" whose accent folding could be checked
+	if langIt.HasAccents || !langIt.HasPrefix {
+		t.Fatalf("Unexpected Italian language flags: %+v", langIt)
+	}
+	for i, word := range langIt.Words {
+		for _, r := range word {
+			if r > unicode.MaxASCII {
+				t.Fatalf("Italian word %q (%d) is not ASCII", word, i)
+			}
+		}
+	}
+	if idx := langIt.FindWord("perche"); idx >= 0 {
+		t.Errorf("FindWord(\"perche\") = %d, expected no match", idx)
+	}
+
+	seed, decodedLang, err := Decode(expectedPhraseIt1, CoinMonero)
+	if err != nil {
+		t.Fatalf("Failed to decode phrase: %v", err)
+	}
+	defer seed.Free()
+	if decodedLang != langIt {
+		t.Errorf("Expected Italian, got %s", decodedLang.GetLangNameEn())
+	}
+
+	secret := seed.Secret()
+	expected := append([]byte(nil), randBytes2...)
+	expected[internal.SecretSize-1] &= internal.ClearMask
+	if !bytes.Equal(secret, expected) {
+		t.Errorf("Secret mismatch:\nExpected: %x\nGot:      %x", expected, secret)
+	}
+	if birthday := seed.GetBirthday(); birthday != birthdayDecode(birthdayEncode(seedTime2)) {
+		t.Errorf("Unexpected birthday %d", birthday)
+	}
+}
+
+func TestChineseSimplifiedVector(t *testing.T) {
+	langZhS := lang.GetLangByName("Chinese (Simplified)")
+
+	seed := newTestSeed(t)
+
+	if phrase := seed.Encode(langZhS, CoinMonero); phrase != expectedPhraseZhS1 {
+		t.Errorf("Encoding failed:\nExpected: %q\nGot:      %q", expectedPhraseZhS1, phrase)
+	}
+
+	// Decodes with, without or with some separators, without colliding
+	// with Traditional
+	words := strings.Fields(expectedPhraseZhS1)
+	var pairs []string
+	for i := 0; i < len(words); i += 2 {
+		pairs = append(pairs, words[i]+words[i+1])
+	}
+	for _, phrase := range []string{
+		expectedPhraseZhS1,
+		strings.ReplaceAll(expectedPhraseZhS1, " ", ""),
+		strings.Join(pairs, " "),
+	} {
+		decoded, decodedLang, err := Decode(phrase, CoinMonero)
+		if err != nil {
+			t.Fatalf("Failed to decode %q: %v", phrase, err)
+		}
+		if decodedLang != langZhS || !decoded.Equal(seed) {
+			t.Errorf("Decoded seed mismatch for %q", phrase)
+		}
+		decoded.Free()
+	}
+}
+
+func TestNoSpaceSplit(t *testing.T) {
+	for i := 0; i < GetNumLangs(); i++ {
+		l := GetLang(i)
+		if l.NoSpaceSplit && l.HasPrefix {
+			t.Errorf("%s: NoSpaceSplit with prefix matching", l.NameEn)
+		}
+	}
+	for _, name := range []string{"Japanese", "Chinese (Simplified)", "Chinese (Traditional)"} {
+		if !lang.GetLangByName(name).NoSpaceSplit {
+			t.Errorf("%s: expected NoSpaceSplit", name)
+		}
+	}
+
+	langZhS := lang.GetLangByName("Chinese (Simplified)")
+	words := strings.Fields(expectedPhraseZhS1)
+	parts := []string{strings.Join(words[:5], ""), strings.Join(words[5:], "")}
+	if segmented := lang.SegmentParts(parts, langZhS); !slices.Equal(segmented, words) {
+		t.Errorf("SegmentParts = %q, expected %q", segmented, words)
+	}
+	if segmented := lang.SegmentPhrase(strings.Join(words, ""), lang.GetLangByName("English")); segmented != nil {
+		t.Errorf("English phrase segmented: %q", segmented)
+	}
+
+	// Words never span two parts
+	seed := newTestSeed(t)
+	langJp := lang.GetLangByName("Japanese")
+	jpWords := SplitPhrase(seed.Encode(langJp, CoinMonero))
+	first := []rune(jpWords[0])
+	split := []string{string(first[:1]), string(first[1:]) + strings.Join(jpWords[1:], "")}
+	if segmented := lang.SegmentParts(split, langJp); segmented != nil && segmented[0] == jpWords[0] {
+		t.Errorf("Word spanning two parts: %q", segmented)
+	}
+	if segmented := lang.SegmentParts([]string{strings.Join(jpWords, "")}, langJp); !slices.Equal(segmented, jpWords) {
+		t.Errorf("SegmentParts = %q, expected %q", segmented, jpWords)
+	}
+
+	// Fewer than 16 words in a language without NoSpaceSplit
+	if _, _, err := Decode(strings.Join(strings.Fields(expectedPhraseEn1)[:15], " "), CoinMonero); !errors.Is(err, StatusErrNumWords) {
+		t.Errorf("Expected StatusErrNumWords, got %v", err)
+	}
+
+	broken := lang.LangEn
+	broken.NameEn = "Broken"
+	broken.Code = ""
+	broken.NoSpaceSplit = true
+	if err := broken.Verify(); !errors.Is(err, lang.ErrWordlist) {
+		t.Errorf("NoSpaceSplit with prefix: expected ErrWordlist, got %v", err)
+	}
+}
+
+func TestKoreanVector(t *testing.T) {
+	langKo := lang.GetLangByName("Korean")
+	if !langKo.Compose || langKo.HasPrefix || langKo.HasAccents {
+		t.Fatalf("Unexpected Korean language flags: %+v", langKo)
+	}
+
+	seed := newTestSeed(t)
+
+	// Encoding composes the Hangul syllables
+	phrase := seed.Encode(langKo, CoinMonero)
+	if phrase != expectedPhraseKo1 || !norm.NFC.IsNormalString(phrase) {
+		t.Errorf("Encoding failed:\nExpected: %q\nGot:      %q", expectedPhraseKo1, phrase)
+	}
+
+	// Composed syllables and decomposed jamo decode alike
+	for _, phrase := range []string{expectedPhraseKo1, norm.NFD.String(expectedPhraseKo1)} {
+		decoded, decodedLang, err := Decode(phrase, CoinMonero)
+		if err != nil {
+			t.Fatalf("Failed to decode %q: %v", phrase, err)
+		}
+		if decodedLang != langKo || !decoded.Equal(seed) {
+			t.Errorf("Decoded seed mismatch for %q", phrase)
+		}
+		decoded.Free()
+	}
+}
+
+func TestChineseTraditionalVector(t *testing.T) {
+	langZhS := lang.GetLangByName("Chinese (Simplified)")
+	langZhT := lang.GetLangByName("Chinese (Traditional)")
+
+	seed := newTestSeed(t)
+
+	if phrase := seed.Encode(langZhT, CoinMonero); phrase != expectedPhraseZhT1 {
+		t.Errorf("Encoding failed:\nExpected: %q\nGot:      %q", expectedPhraseZhT1, phrase)
+	}
+
+	// The phrase is only valid in Traditional, so auto-detection does not
+	// report ErrMultLang
+	words := strings.Fields(expectedPhraseZhT1)
+	if _, err := lang.PhraseDecodeExplicit(words, langZhS); err != lang.ErrLang {
+		t.Errorf("Traditional phrase accepted as Simplified: %v", err)
+	}
+	for _, phrase := range []string{expectedPhraseZhT1, strings.ReplaceAll(expectedPhraseZhT1, " ", "")} {
+		decoded, decodedLang, err := Decode(phrase, CoinMonero)
+		if err != nil {
+			t.Fatalf("Failed to decode %q: %v", phrase, err)
+		}
+		if decodedLang != langZhT || !decoded.Equal(seed) {
+			t.Errorf("Decoded seed mismatch for %q", phrase)
+		}
+		decoded.Free()
+	}
+}
+
+func TestAccentFolding(t *testing.T) {
+	langEs := lang.GetLangByName("Spanish")
+
+	for _, pair := range [][2]string{
+		{"célebre", "celebre"},
+		{"pestaña", "pestana"},
+		{"prisión", "prision"},
+	} {
+		accented := langEs.FindWord(UTF8NFKDLazy(pair[0]))
+		if accented < 0 || langEs.FindWord(pair[1]) != accented {
+			t.Errorf("%q and %q do not resolve to the same word", pair[0], pair[1])
+		}
+	}
+
+	// Non-ASCII letters that are not accents are preserved
+	if idx := langEs.FindWord("celebreø"); idx >= 0 {
+		t.Errorf("Expected no match for a different letter, got %d", idx)
+	}
+
+	decoded, _, err := Decode(expectedPhraseEs2, CoinMonero)
+	if err != nil {
+		t.Fatalf("Failed to decode phrase without accents: %v", err)
+	}
+	decoded.Free()
+}
+
+func TestRecoverWord(t *testing.T) {
+	langEn := lang.GetLangByName("English")
+	words := strings.Fields(expectedPhraseEn1)
+
+	for _, missing := range []int{0, 1, 7, NumWords - 1} {
+		known := append(append([]string(nil), words[:missing]...), words[missing+1:]...)
+		candidates, foundLang, err := RecoverWord(known, missing, CoinMonero)
+		if err != nil {
+			t.Fatalf("Failed to recover word %d: %v", missing, err)
+		}
+		if foundLang != langEn {
+			t.Errorf("Expected English, got %s", foundLang.GetLangNameEn())
+		}
+		if len(candidates) != 1 || candidates[0] != words[missing] {
+			t.Errorf("Word %d: expected %q, got %v", missing, words[missing], candidates)
+		}
+	}
+
+	if _, _, err := RecoverWord(words, 0, CoinMonero); !errors.Is(err, StatusErrNumWords) {
+		t.Errorf("Expected StatusErrNumWords, got %v", err)
+	}
+	for _, missing := range []int{-1, NumWords} {
+		if _, _, err := RecoverWord(words[1:], missing, CoinMonero); !errors.Is(err, ErrWordIndex) {
+			t.Errorf("Index %d: expected ErrWordIndex, got %v", missing, err)
+		}
+	}
+}
+
+func TestChecksumWord(t *testing.T) {
+	langEn := lang.GetLangByName("English")
+	words := strings.Fields(expectedPhraseEn1)
+
+	word, err := ChecksumWord(words[1:], langEn, CoinMonero)
+	if err != nil {
+		t.Fatalf("Failed to compute checksum word: %v", err)
+	}
+	if word != words[0] {
+		t.Errorf("Expected %q, got %q", words[0], word)
+	}
+
+	// The result is a valid phrase for other coins as well
+	word, err = ChecksumWord(words[1:], langEn, CoinAeon)
+	if err != nil {
+		t.Fatalf("Failed to compute checksum word: %v", err)
+	}
+	phrase := word + " " + strings.Join(words[1:], " ")
+	if _, _, err := Decode(phrase, CoinAeon); err != nil {
+		t.Errorf("Failed to decode phrase: %v", err)
+	}
+
+	if _, err := ChecksumWord(words, langEn, CoinMonero); !errors.Is(err, StatusErrNumWords) {
+		t.Errorf("Expected StatusErrNumWords, got %v", err)
+	}
+}
+
+func TestSuggest(t *testing.T) {
+	langEn := lang.GetLangByName("English")
+	if words := langEn.Suggest("rave", 5); len(words) != 1 || words[0] != "raven" {
+		t.Errorf("Expected [raven], got %v", words)
+	}
+	if words := langEn.Suggest("ab", 3); len(words) != 3 || words[0] != "abandon" {
+		t.Errorf("Expected 3 suggestions starting with abandon, got %v", words)
+	}
+	if words := langEn.Suggest("ab", 0); len(words) < 3 {
+		t.Errorf("Expected all suggestions, got %v", words)
+	}
+
+	// Accents are ignored
+	langEs := lang.GetLangByName("Spanish")
+	for _, prefix := range []string{"cele", "céle"} {
+		words := langEs.Suggest(prefix, 10)
+		if len(words) != 1 || norm.NFC.String(words[0]) != "célebre" {
+			t.Errorf("Suggest(%q): expected [célebre], got %v", prefix, words)
+		}
+	}
+}
+
+func TestFindClosest(t *testing.T) {
+	tests := []struct {
+		lang     string
+		word     string
+		expected string
+	}{
+		{"English", "infnat", "infant"},
+		{"English", "raven", "raven"},
+		{"English", "lagnuage", "language"},
+		{"Spanish", "pestanya", "pestaña"},
+		{"French", "sinsère", "sincère"},
+		{"Czech", "ropovot", "ropovod"},
+	}
+	for _, tt := range tests {
+		l := lang.GetLangByName(tt.lang)
+		idx, suggestion, ok := l.FindClosest(tt.word, 2)
+		if !ok || norm.NFC.String(suggestion) != tt.expected || l.Words[idx] != suggestion {
+			t.Errorf("%s FindClosest(%q) = %d, %q, %v; expected %q",
+				tt.lang, tt.word, idx, suggestion, ok, tt.expected)
+		}
+	}
+
+	if _, _, ok := lang.GetLangByName("English").FindClosest("xxxxxxxxxx", 2); ok {
+		t.Error("Expected no match")
+	}
+}
+
+func TestDecodeVerbose(t *testing.T) {
+	seed, foundLang, report, err := DecodeVerbose(expectedPhraseEn1, CoinMonero)
+	if err != nil || report != nil || foundLang != lang.GetLangByName("English") {
+		t.Fatalf("Unexpected result: %v, %v", report, err)
+	}
+	seed.Free()
+
+	words := strings.Fields(expectedPhraseEn1)
+	words[5] = "asist"
+	_, _, report, err = DecodeVerbose(strings.Join(words, " "), CoinMonero)
+	if err != StatusErrLang || report == nil {
+		t.Fatalf("Expected StatusErrLang with a report, got %v", err)
+	}
+	if len(report.Langs) != GetNumLangs() {
+		t.Errorf("Expected %d language reports, got %d", GetNumLangs(), len(report.Langs))
+	}
+	for _, r := range report.Langs {
+		if r.Lang.GetLangNameEn() == "English" && (r.WordIndex != 5 || r.Word != "asist") {
+			t.Errorf("Unexpected English report: %+v", r)
+		}
+	}
+}
+
+func TestDecodeVerboseMixed(t *testing.T) {
+	langEn := lang.GetLangByName("English")
+	langEs := lang.GetLangByName("Spanish")
+	words := append(strings.Fields(expectedPhraseEn1)[:8], strings.Fields(NormalizePhrase(expectedPhraseEs1))[8:]...)
+	phrase := strings.Join(words, " ")
+
+	_, _, report, err := DecodeVerbose(phrase, CoinMonero)
+	if err != StatusErrLang || report == nil {
+		t.Fatalf("Expected StatusErrLang with a report, got %v", err)
+	}
+	if !report.Mixed() {
+		t.Error("Expected a mixed phrase")
+	}
+	if len(report.Words) != NumWords {
+		t.Fatalf("Expected %d word reports, got %d", NumWords, len(report.Words))
+	}
+	for i, w := range report.Words {
+		expected := langEn
+		if i >= 8 {
+			expected = langEs
+		}
+		if w.Word != words[i] || !slices.Contains(w.Langs, expected) {
+			t.Errorf("Word %d: %q is recognized by %v, expected %s", i, w.Word, w.Langs, expected.NameEn)
+		}
+	}
+
+	words = strings.Fields(expectedPhraseEn1)
+	words[5] = "xyzzy"
+	_, _, report, _ = DecodeVerbose(strings.Join(words, " "), CoinMonero)
+	if report.Mixed() || len(report.Words[5].Langs) != 0 {
+		t.Errorf("Unknown word reported as mixed: %+v", report.Words[5])
+	}
+	if r := CheckPhraseLanguages(expectedPhraseEn1); len(r) != NumWords || !slices.Contains(r[0].Langs, langEn) {
+		t.Errorf("CheckPhraseLanguages = %v", r)
+	}
+}
+
+func TestSplitPhrase(t *testing.T) {
+	words := SplitPhrase("  eje\tfin\n célebre  ")
+	if len(words) != 3 || words[2] != NormalizePhrase("célebre") {
+		t.Errorf("Unexpected words: %q", words)
+	}
+	if NormalizePhrase("raven") != "raven" {
+		t.Error("ASCII phrase should not change")
+	}
+	if !norm.NFKD.IsNormalString(NormalizePhrase(expectedPhraseEs1)) {
+		t.Error("Expected phrase in NFKD form")
+	}
+}
+
+func TestEncodeWithSeparator(t *testing.T) {
+	seed := newTestSeed(t)
+	langEn := lang.GetLangByName("English")
+
+	phrase := seed.EncodeWithSeparator(langEn, CoinMonero, "\n")
+	if phrase != strings.ReplaceAll(expectedPhraseEn1, " ", "\n") {
+		t.Errorf("Unexpected phrase: %q", phrase)
+	}
+	decoded, _, err := Decode(phrase, CoinMonero)
+	if err != nil {
+		t.Fatalf("Failed to decode phrase: %v", err)
+	}
+	defer decoded.Free()
+	if !decoded.Equal(seed) {
+		t.Error("Decoded seed mismatch")
+	}
+
+	phrase = seed.EncodeWithSeparator(langEn, CoinMonero, ",")
+	if len(strings.Split(phrase, ",")) != NumWords {
+		t.Errorf("Unexpected phrase: %q", phrase)
+	}
+}
+
+func TestDecodeAnyCoin(t *testing.T) {
+	seed := newTestSeed(t)
+	langEn := lang.GetLangByName("English")
+
+	for _, coin := range []Coin{CoinMonero, CoinAeon, CoinWownero} {
+		decoded, decodedLang, decodedCoin, err := DecodeAnyCoin(seed.Encode(langEn, coin))
+		if err != nil {
+			t.Fatalf("Coin %d: failed to decode: %v", coin, err)
+		}
+		if decodedCoin != coin || decodedLang != langEn || !decoded.Equal(seed) {
+			t.Errorf("Coin %d: decoded as coin %d", coin, decodedCoin)
+		}
+		decoded.Free()
+	}
+
+	if _, _, _, err := DecodeAnyCoin(seed.Encode(langEn, 100)); err != StatusErrChecksum {
+		t.Errorf("Expected StatusErrChecksum, got %v", err)
+	}
+	if _, _, _, err := DecodeAnyCoin("raven"); !errors.Is(err, StatusErrNumWords) {
+		t.Errorf("Expected StatusErrNumWords, got %v", err)
+	}
+}
+
+func TestValidatePhrase(t *testing.T) {
+	if err := ValidatePhrase(expectedPhraseEn1, CoinMonero); err != nil {
+		t.Errorf("Expected valid phrase, got %v", err)
+	}
+
+	words := strings.Fields(expectedPhraseEn1)
+	tests := []struct {
+		phrase   string
+		coin     Coin
+		expected error
+	}{
+		{expectedPhraseEn1, CoinAeon, StatusErrChecksum},
+		{strings.Join(words[1:], " "), CoinMonero, StatusErrNumWords},
+		{strings.Join(append([]string{"notaword"}, words[1:]...), " "), CoinMonero, StatusErrLang},
+		{strings.Join(append([]string{"zoo"}, words[1:]...), " "), CoinMonero, StatusErrChecksum},
+	}
+	for _, tt := range tests {
+		if err := ValidatePhrase(tt.phrase, tt.coin); !errors.Is(err, tt.expected) {
+			t.Errorf("ValidatePhrase(%q): expected %v, got %v", tt.phrase, tt.expected, err)
+		}
+		if _, _, err := Decode(tt.phrase, tt.coin); !errors.Is(err, tt.expected) {
+			t.Errorf("Decode(%q): expected %v, got %v", tt.phrase, tt.expected, err)
+		}
+	}
+
+	// Features are checked as well
+	EnableFeatures(2)
+	seed, err := CreateWithBirthday(randBytes1, seedTime1, 2)
+	EnableFeatures(0)
+	if err != nil {
+		t.Fatalf("Failed to create seed: %v", err)
+	}
+	defer seed.Free()
+	phrase := seed.Encode(lang.GetLangByName("English"), CoinMonero)
+	if err := ValidatePhrase(phrase, CoinMonero); err != StatusErrUnsupported {
+		t.Errorf("Expected StatusErrUnsupported, got %v", err)
+	}
+}
+
+func TestFeatureSet(t *testing.T) {
+	fsNone := NewFeatureSet(0)
+	fsFirst := NewFeatureSet(1)
+
+	seed, err := fsFirst.Create(1)
+	if err != nil {
+		t.Fatalf("Failed to create seed: %v", err)
+	}
+	defer seed.Free()
+	if _, err := fsNone.Create(1); err != StatusErrUnsupported {
+		t.Errorf("Expected StatusErrUnsupported, got %v", err)
+	}
+	phrase := seed.Encode(lang.GetLangByName("English"), CoinMonero)
+
+	// Feature sets can be used concurrently with the global configuration
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(3)
+		go func() {
+			defer wg.Done()
+			decoded, _, err := fsFirst.Decode(phrase, CoinMonero)
+			if err != nil {
+				t.Errorf("Failed to decode phrase: %v", err)
+				return
+			}
+			decoded.Free()
+		}()
+		go func() {
+			defer wg.Done()
+			if _, _, err := fsNone.Decode(phrase, CoinMonero); err != StatusErrUnsupported {
+				t.Errorf("Expected StatusErrUnsupported, got %v", err)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			EnableFeatures(0)
+		}()
+	}
+	wg.Wait()
+
+	if _, _, err := Decode(phrase, CoinMonero); err != StatusErrUnsupported {
+		t.Errorf("Expected StatusErrUnsupported from the default configuration, got %v", err)
+	}
+}
+
+func TestSetFeature(t *testing.T) {
+	EnableFeatures(3)
+	defer EnableFeatures(0)
+
+	seed := newTestSeed(t)
+	langEn := lang.GetLangByName("English")
+
+	if err := seed.SetFeature(2, true); err != nil {
+		t.Fatalf("Failed to set feature: %v", err)
+	}
+	if !seed.HasFeature(2) || seed.HasFeature(1) {
+		t.Error("Unexpected features")
+	}
+	decoded, _, err := Decode(seed.Encode(langEn, CoinMonero), CoinMonero)
+	if err != nil {
+		t.Fatalf("Failed to decode phrase: %v", err)
+	}
+	defer decoded.Free()
+	if !decoded.Equal(seed) {
+		t.Error("Decoded seed mismatch")
+	}
+
+	if err := seed.SetFeature(2, false); err != nil {
+		t.Fatalf("Failed to clear feature: %v", err)
+	}
+	if phrase := seed.Encode(langEn, CoinMonero); phrase != expectedPhraseEn1 {
+		t.Errorf("Unexpected phrase: %q", phrase)
+	}
+
+	// Disabled and reserved bits are rejected
+	for _, mask := range []uint8{4, 8, encryptedMask} {
+		if err := seed.SetFeature(mask, true); err != StatusErrUnsupported {
+			t.Errorf("SetFeature(%d): expected StatusErrUnsupported, got %v", mask, err)
+		}
+	}
+}
+
+func TestFeatureNames(t *testing.T) {
+	fs := NewFeatureSet(5)
+	seed, err := fs.Create(5)
+	if err != nil {
+		t.Fatalf("Failed to create seed: %v", err)
+	}
+	defer seed.Free()
+
+	RegisterFeatureName(0, "two-factor")
+	RegisterFeatureName(7, "ignored")
+	names := seed.FeatureNames()
+	if len(names) != 2 || names[0] != "two-factor" || names[1] != "feature 2" {
+		t.Errorf("Unexpected feature names: %q", names)
+	}
+}
+
+func TestBirthdayTime(t *testing.T) {
+	seed := newTestSeed(t)
+
+	birthday := seed.BirthdayTime()
+	if birthday.Unix() != 1638397746 || birthday.Location() != time.UTC {
+		t.Errorf("Unexpected birthday: %v", birthday)
+	}
+
+	start, end := seed.BirthdayRange()
+	created := time.Unix(int64(seedTime1), 0)
+	if !start.Equal(birthday) || created.Before(start) || !created.Before(end) {
+		t.Errorf("Creation time %v not in [%v, %v)", created, start, end)
+	}
+	if end.Sub(start) != time.Duration(timeStep)*time.Second {
+		t.Errorf("Unexpected range length: %v", end.Sub(start))
+	}
+}
+
+func TestEncodeBirthday(t *testing.T) {
+	tests := []struct {
+		timestamp uint64
+		expected  uint16
+	}{
+		{0, 0},
+		{epoch - 1, 0},
+		{^uint64(0), 0},
+		{epoch, 0},
+		{seedTime1, 1},
+		{epoch + timeStep - 1, 0},
+		{epoch + timeStep, 1},
+	}
+	for _, tt := range tests {
+		if b := EncodeBirthday(tt.timestamp); b != tt.expected {
+			t.Errorf("EncodeBirthday(%d) = %d, expected %d", tt.timestamp, b, tt.expected)
+		}
+	}
+	if ts := DecodeBirthday(1); ts != 1638397746 {
+		t.Errorf("DecodeBirthday(1) = %d", ts)
+	}
+}
+
+func TestEncodeBirthdayChecked(t *testing.T) {
+	last := epoch + DateMask*timeStep
+	for _, timestamp := range []uint64{0, seedTime1, seedTime2, last, last + timeStep - 1} {
+		if _, ok := EncodeBirthdayChecked(timestamp); !ok {
+			t.Errorf("EncodeBirthdayChecked(%d): unexpected overflow", timestamp)
+		}
+	}
+	for _, timestamp := range []uint64{last + timeStep, seedTime3 + 100*timeStep} {
+		if b, ok := EncodeBirthdayChecked(timestamp); ok || b != EncodeBirthday(timestamp) {
+			t.Errorf("EncodeBirthdayChecked(%d) = %d, %v; expected overflow", timestamp, b, ok)
+		}
+	}
+}
+
+func TestStorageHex(t *testing.T) {
+	seed := newTestSeed(t)
+
+	var storage Storage
+	seed.Store(&storage)
+	str := storage.Hex()
+	if len(str) != 2*StorageSize {
+		t.Fatalf("Hex length = %d, expected %d", len(str), 2*StorageSize)
+	}
+
+	parsed, err := ParseStorageHex(strings.ToUpper(str))
+	if err != nil {
+		t.Fatalf("ParseStorageHex failed: %v", err)
+	}
+	if *parsed != storage {
+		t.Error("parsed storage does not match")
+	}
+	loaded, err := Load(parsed)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	defer loaded.Free()
+	if !loaded.Equal(seed) {
+		t.Error("loaded seed does not match")
+	}
+
+	for _, bad := range []string{"", str[:62], str + "00", str[:63] + "g"} {
+		if _, err := ParseStorageHex(bad); err != StatusErrFormat {
+			t.Errorf("ParseStorageHex(%q): expected StatusErrFormat, got %v", bad, err)
+		}
+	}
+}
+
+func TestStorageBase64(t *testing.T) {
+	seed := newTestSeed(t)
+
+	var storage Storage
+	seed.Store(&storage)
+	str := storage.Base64()
+	if strings.ContainsAny(str, "+/=") {
+		t.Errorf("Base64 output %q is not URL-safe and unpadded", str)
+	}
+
+	parsed, err := ParseStorageBase64(str)
+	if err != nil {
+		t.Fatalf("ParseStorageBase64 failed: %v", err)
+	}
+	if *parsed != storage {
+		t.Error("parsed storage does not match")
+	}
+
+	for _, bad := range []string{"", str[:40], str + "AA", str[:42] + "*"} {
+		if _, err := ParseStorageBase64(bad); err != StatusErrFormat {
+			t.Errorf("ParseStorageBase64(%q): expected StatusErrFormat, got %v", bad, err)
+		}
+	}
+}
+
+func TestWriteToReadSeed(t *testing.T) {
+	seed := newTestSeed(t)
+
+	var buf bytes.Buffer
+	n, err := seed.WriteTo(&buf)
+	if err != nil {
+		t.Fatalf("WriteTo failed: %v", err)
+	}
+	if n != StorageSize || buf.Len() != StorageSize {
+		t.Fatalf("WriteTo wrote %d bytes, expected %d", n, StorageSize)
+	}
+
+	// Trailing data is left in the reader
+	buf.WriteString("tail")
+	loaded, err := ReadSeed(&buf)
+	if err != nil {
+		t.Fatalf("ReadSeed failed: %v", err)
+	}
+	defer loaded.Free()
+	if !loaded.Equal(seed) {
+		t.Error("loaded seed does not match")
+	}
+	if buf.String() != "tail" {
+		t.Errorf("ReadSeed consumed too much, %q left", buf.String())
+	}
+
+	if _, err := ReadSeed(bytes.NewReader(make([]byte, StorageSize-1))); err != io.ErrUnexpectedEOF {
+		t.Errorf("ReadSeed short input: expected io.ErrUnexpectedEOF, got %v", err)
+	}
+	if _, err := ReadSeed(bytes.NewReader(make([]byte, StorageSize))); err != StatusErrFormat {
+		t.Errorf("ReadSeed zero input: expected StatusErrFormat, got %v", err)
+	}
+}
+
+func TestStorageValidate(t *testing.T) {
+	seed := newTestSeed(t)
+
+	var storage Storage
+	seed.Store(&storage)
+	if err := storage.Validate(); err != nil {
+		t.Errorf("Validate failed: %v", err)
+	}
+
+	corrupt := storage
+	corrupt[20] ^= 0x01
+	if err := corrupt.Validate(); err != StatusErrChecksum {
+		t.Errorf("corrupted storage: expected StatusErrChecksum, got %v", err)
+	}
+
+	var empty Storage
+	if err := empty.Validate(); err != StatusErrFormat {
+		t.Errorf("empty storage: expected StatusErrFormat, got %v", err)
+	}
+}
+
+func TestChangePassword(t *testing.T) {
+	seed := newTestSeed(t)
+
+	if err := seed.ChangePassword("old", "new"); err != ErrNotEncrypted {
+		t.Errorf("unencrypted seed: expected ErrNotEncrypted, got %v", err)
+	}
+
+	changed := seed.Clone()
+	defer changed.Free()
+	if err := changed.Crypt("old"); err != nil {
+		t.Fatalf("Crypt failed: %v", err)
+	}
+	if err := changed.ChangePassword("old", " "); err != ErrEmptyPassword {
+		t.Errorf("empty new password: expected ErrEmptyPassword, got %v", err)
+	}
+	if err := changed.ChangePassword("old", "new"); err != nil {
+		t.Fatalf("ChangePassword failed: %v", err)
+	}
+
+	expected := seed.Clone()
+	defer expected.Free()
+	if err := expected.Crypt("new"); err != nil {
+		t.Fatalf("Crypt failed: %v", err)
+	}
+	if !changed.Equal(expected) {
+		t.Error("seed re-encrypted with ChangePassword does not match")
+	}
+
+	lang := lang.GetLangByName("English")
+	if _, _, err := Decode(changed.Encode(lang, CoinMonero), CoinMonero); err != nil {
+		t.Errorf("Decode after ChangePassword failed: %v", err)
+	}
+
+	if err := changed.Crypt("new"); err != nil {
+		t.Fatalf("Crypt failed: %v", err)
+	}
+	if !changed.Equal(seed) {
+		t.Error("seed decrypted with the new password does not match")
+	}
+}
+
+func TestEncryptDecrypt(t *testing.T) {
+	seed := newTestSeed(t)
+
+	if err := seed.Decrypt("password"); err != ErrNotEncrypted {
+		t.Errorf("Decrypt unencrypted seed: expected ErrNotEncrypted, got %v", err)
+	}
+
+	encrypted := seed.Clone()
+	defer encrypted.Free()
+	if err := encrypted.Encrypt(""); err != ErrEmptyPassword {
+		t.Errorf("Encrypt empty password: expected ErrEmptyPassword, got %v", err)
+	}
+	if err := encrypted.Encrypt("password"); err != nil {
+		t.Fatalf("Encrypt failed: %v", err)
+	}
+	if !encrypted.IsEncrypted() {
+		t.Fatal("seed is not encrypted after Encrypt")
+	}
+
+	snapshot := encrypted.Clone()
+	defer snapshot.Free()
+	if err := encrypted.Encrypt("password"); err != ErrEncrypted {
+		t.Errorf("Encrypt twice: expected ErrEncrypted, got %v", err)
+	}
+	if !encrypted.Equal(snapshot) {
+		t.Error("failed Encrypt modified the seed")
+	}
+
+	// A wrong password is not detected
+	wrong := encrypted.Clone()
+	defer wrong.Free()
+	if err := wrong.Decrypt("wrong password"); err != nil {
+		t.Fatalf("Decrypt with a wrong password failed: %v", err)
+	}
+	if wrong.IsEncrypted() || wrong.Equal(seed) || wrong.Validate() != nil {
+		t.Error("expected a valid unencrypted seed with a different secret")
+	}
+
+	if err := encrypted.Decrypt("password"); err != nil {
+		t.Fatalf("Decrypt failed: %v", err)
+	}
+	if !encrypted.Equal(seed) {
+		t.Error("decrypted seed does not match")
+	}
+}
+
+// countingKDF wraps PBKDF2 with a low iteration count and counts calls
+type countingKDF struct {
+	calls int
+}
+
+func (k *countingKDF) Derive(password, salt []byte, keyLen int) []byte {
+	k.calls++
+	return PBKDF2KDF{Iterations: 1}.Derive(password, salt, keyLen)
+}
+
+func TestArgon2idKDF(t *testing.T) {
+	// Vectors of the reference Argon2 implementation
+	vectors := []struct {
+		kdf      Argon2idKDF
+		expected string
+	}{
+		{Argon2idKDF{Time: 1, Memory: 64, Threads: 1}, "655ad15eac652dc59f7170a7332bf49b8469be1fdb9c28bb"},
+		{Argon2idKDF{Time: 2, Memory: 64, Threads: 2}, "350ac37222f436ccb5c0972f1ebd3bf6b958bf2071841362"},
+		{Argon2idKDF{Time: 3, Memory: 256, Threads: 2}, "4668d30ac4187e6878eedeacf0fd83c5a0a30db2cc16ef0b"},
+	}
+	for _, v := range vectors {
+		if key := hex.EncodeToString(v.kdf.Derive([]byte("password"), []byte("somesalt"), 24)); key != v.expected {
+			t.Errorf("%+v: got %s, expected %s", v.kdf, key, v.expected)
+		}
+	}
+
+	defaults := Argon2idKDF{}.Derive([]byte("password"), []byte("somesalt"), 32)
+	explicit := Argon2idKDF{Time: 3, Memory: 64 * 1024, Threads: 4}.Derive([]byte("password"), []byte("somesalt"), 32)
+	if !bytes.Equal(defaults, explicit) {
+		t.Error("zero parameters do not use the RFC 9106 defaults")
+	}
+
+	seed := newTestSeed(t)
+	kdf := Argon2idKDF{Time: 1, Memory: 64, Threads: 1}
+	if bytes.Equal(seed.KeygenWithKDF(CoinMonero, 32, kdf), seed.Keygen(CoinMonero, 32)) {
+		t.Error("Argon2id produced the PBKDF2 key")
+	}
+	encrypted := seed.Clone()
+	defer encrypted.Free()
+	for range 2 {
+		if err := encrypted.CryptWithKDF("password", kdf); err != nil {
+			t.Fatalf("CryptWithKDF failed: %v", err)
+		}
+	}
+	if !encrypted.Equal(seed) {
+		t.Error("Argon2id encryption roundtrip failed")
+	}
+}
+
+func TestKDF(t *testing.T) {
+	// The default KDF must keep producing the specification's results
+	const expectedKey1 = "21268a76048a3b25a4a9ac179d86b12fab5800b8d858da9facf4b0a778dc2840"
+	const expectedEncrypted1 = "just blood lemon limb kiss head name stem seek swift throw pattern guitar orchard pelican rifle"
+
+	seed := newTestSeed(t)
+	langEn := lang.GetLangByName("English")
+
+	if key := hex.EncodeToString(seed.Keygen(CoinMonero, 32)); key != expectedKey1 {
+		t.Errorf("Keygen = %s, expected %s", key, expectedKey1)
+	}
+	if key := hex.EncodeToString(seed.KeygenWithKDF(CoinMonero, 32, PBKDF2KDF{})); key != expectedKey1 {
+		t.Errorf("KeygenWithKDF(PBKDF2KDF{}) = %s, expected %s", key, expectedKey1)
+	}
+
+	encrypted := seed.Clone()
+	defer encrypted.Free()
+	if err := encrypted.Crypt("password"); err != nil {
+		t.Fatalf("Crypt failed: %v", err)
+	}
+	if phrase := encrypted.Encode(langEn, CoinMonero); phrase != expectedEncrypted1 {
+		t.Errorf("encrypted phrase = %q, expected %q", phrase, expectedEncrypted1)
+	}
+
+	// A custom KDF is used and gives different results
+	kdf := &countingKDF{}
+	if key := hex.EncodeToString(seed.KeygenWithKDF(CoinMonero, 32, kdf)); key == expectedKey1 {
+		t.Error("KeygenWithKDF with a custom KDF produced the default key")
+	}
+	custom := seed.Clone()
+	defer custom.Free()
+	if err := custom.CryptWithKDF("", kdf); err != ErrEmptyPassword {
+		t.Errorf("CryptWithKDF empty password: expected ErrEmptyPassword, got %v", err)
+	}
+	if err := custom.CryptWithKDF("password", kdf); err != nil {
+		t.Fatalf("CryptWithKDF failed: %v", err)
+	}
+	if custom.Equal(encrypted) {
+		t.Error("CryptWithKDF with a custom KDF produced the default encryption")
+	}
+	if err := custom.CryptWithKDF("password", kdf); err != nil {
+		t.Fatalf("CryptWithKDF failed: %v", err)
+	}
+	if !custom.Equal(seed) {
+		t.Error("CryptWithKDF roundtrip does not match")
+	}
+	if kdf.calls != 3 {
+		t.Errorf("custom KDF called %d times, expected 3", kdf.calls)
+	}
+}
+
+func TestCryptWithOptions(t *testing.T) {
+	seed := newTestSeed(t)
+
+	// The zero value uses the default iteration count
+	def := seed.Clone()
+	defer def.Free()
+	if err := def.CryptWithOptions("password", CryptOptions{}); err != nil {
+		t.Fatalf("CryptWithOptions failed: %v", err)
+	}
+	expected := seed.Clone()
+	defer expected.Free()
+	if err := expected.Crypt("password"); err != nil {
+		t.Fatalf("Crypt failed: %v", err)
+	}
+	if !def.Equal(expected) {
+		t.Error("CryptWithOptions with default options does not match Crypt")
+	}
+
+	fast := seed.Clone()
+	defer fast.Free()
+	if err := fast.CryptWithOptions("password", CryptOptions{Iterations: -1}); err != ErrIterations {
+		t.Errorf("negative iterations: expected ErrIterations, got %v", err)
+	}
+	negative := PBKDF2KDF{Iterations: -1}
+	if err := fast.CryptWithKDF("password", negative); err != ErrIterations || !fast.Equal(seed) {
+		t.Errorf("CryptWithKDF with negative iterations: expected ErrIterations, got %v", err)
+	}
+	if key, err := negative.DeriveContext(context.Background(), []byte("password"), []byte("salt"), 32); key != nil || err != ErrIterations {
+		t.Errorf("DeriveContext with negative iterations: expected ErrIterations, got %x, %v", key, err)
+	}
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("Derive with negative iterations did not panic")
+			}
+		}()
+		negative.Derive([]byte("password"), []byte("salt"), 32)
+	}()
+	if err := fast.CryptWithOptions("password", CryptOptions{Iterations: 10}); err != nil {
+		t.Fatalf("CryptWithOptions failed: %v", err)
+	}
+	if fast.Equal(expected) {
+		t.Error("a different iteration count produced the same encryption")
+	}
+	if err := fast.CryptWithOptions("password", CryptOptions{Iterations: 10}); err != nil {
+		t.Fatalf("CryptWithOptions failed: %v", err)
+	}
+	if !fast.Equal(seed) {
+		t.Error("CryptWithOptions roundtrip does not match")
+	}
+}
+
+func TestCoinString(t *testing.T) {
+	vectors := []struct {
+		coin Coin
+		name string
+	}{
+		{CoinMonero, "Monero"},
+		{CoinAeon, "Aeon"},
+		{CoinWownero, "Wownero"},
+	}
+	for _, v := range vectors {
+		if s := v.coin.String(); s != v.name {
+			t.Errorf("Coin(%d).String() = %q, expected %q", uint16(v.coin), s, v.name)
+		}
+		for _, name := range []string{v.name, strings.ToLower(v.name), strings.ToUpper(v.name)} {
+			if c, err := ParseCoin(name); err != nil || c != v.coin {
+				t.Errorf("ParseCoin(%q) = %d, %v; expected %d", name, c, err, v.coin)
+			}
+		}
+	}
+
+	if s := Coin(1000).String(); s != "Coin(1000)" {
+		t.Errorf("Coin(1000).String() = %q", s)
+	}
+	for _, name := range []string{"", "Bitcoin", "Coin(0)"} {
+		if _, err := ParseCoin(name); err != ErrCoin {
+			t.Errorf("ParseCoin(%q): expected ErrCoin, got %v", name, err)
+		}
+	}
+}
+
+func TestRegisterCoin(t *testing.T) {
+	coinNamesMu.Lock()
+	saved := maps.Clone(coinNames)
+	coinNamesMu.Unlock()
+	t.Cleanup(func() {
+		coinNamesMu.Lock()
+		coinNames = saved
+		coinNamesMu.Unlock()
+	})
+
+	const coinTest = Coin(2000)
+	if err := RegisterCoin(coinTest, "Testcoin"); err != nil {
+		t.Fatalf("RegisterCoin failed: %v", err)
+	}
+	if s := coinTest.String(); s != "Testcoin" {
+		t.Errorf("String() = %q, expected %q", s, "Testcoin")
+	}
+	if c, err := ParseCoin("testcoin"); err != nil || c != coinTest {
+		t.Errorf("ParseCoin = %d, %v; expected %d", c, err, coinTest)
+	}
+
+	bad := []struct {
+		coin Coin
+		name string
+	}{
+		{internal.GfSize, "Overflow"},
+		{coinTest, "Other"},
+		{CoinMonero, "Monero2"},
+		{2001, "TESTCOIN"},
+		{2001, "monero"},
+		{2001, ""},
+	}
+	for _, v := range bad {
+		if err := RegisterCoin(v.coin, v.name); !errors.Is(err, ErrCoin) {
+			t.Errorf("RegisterCoin(%d, %q): expected ErrCoin, got %v", v.coin, v.name, err)
+		}
+	}
+}
+
+func TestCoinRange(t *testing.T) {
+	seed := newTestSeed(t)
+	langEn := lang.GetLangByName("English")
+
+	phrase, err := seed.EncodeChecked(langEn, CoinMonero)
+	if err != nil || phrase != expectedPhraseEn1 {
+		t.Errorf("EncodeChecked = %q, %v; expected %q", phrase, err, expectedPhraseEn1)
+	}
+	if phrase, err := seed.EncodeChecked(langEn, internal.GfMask); err != nil || phrase == "" {
+		t.Errorf("EncodeChecked with the largest coin failed: %v", err)
+	}
+
+	const coinBad = Coin(internal.GfSize)
+	if _, err := seed.EncodeChecked(langEn, coinBad); err != StatusErrUnsupported {
+		t.Errorf("EncodeChecked: expected StatusErrUnsupported, got %v", err)
+	}
+	if phrase := seed.Encode(langEn, coinBad); phrase != "" {
+		t.Errorf("Encode: expected an empty phrase, got %q", phrase)
+	}
+	if phrase := seed.EncodeWithSeparator(langEn, coinBad, ","); phrase != "" {
+		t.Errorf("EncodeWithSeparator: expected an empty phrase, got %q", phrase)
+	}
+	if _, _, err := Decode(expectedPhraseEn1, coinBad); err != StatusErrUnsupported {
+		t.Errorf("Decode: expected StatusErrUnsupported, got %v", err)
+	}
+	if _, err := DecodeExplicit(expectedPhraseEn1, coinBad, langEn); err != StatusErrUnsupported {
+		t.Errorf("DecodeExplicit: expected StatusErrUnsupported, got %v", err)
+	}
+	if err := ValidatePhrase(expectedPhraseEn1, coinBad); err != StatusErrUnsupported {
+		t.Errorf("ValidatePhrase: expected StatusErrUnsupported, got %v", err)
+	}
+}
+
+func FuzzDecode(f *testing.F) {
+	for _, phrase := range []string{
+		expectedPhraseEn1, expectedPhraseEn2,
+		expectedPhraseEs1, expectedPhraseEs2, expectedPhraseEs3,
+		"", " ", "\xff\xfe", strings.Repeat("raven ", 2000),
+	} {
+		f.Add(phrase, uint16(CoinMonero))
+	}
+	f.Add(expectedPhraseEn1, uint16(internal.GfSize))
+
+	f.Fuzz(func(t *testing.T, phrase string, coin uint16) {
+		seed, foundLang, err := Decode(phrase, Coin(coin))
+		if seed == nil {
+			if err == nil {
+				t.Fatal("Decode returned a nil seed without an error")
+			}
+			return
+		}
+		defer seed.Free()
+		if err != nil || foundLang == nil {
+			t.Fatalf("Decode returned a seed with error %v and language %v", err, foundLang)
+		}
+	})
+}
+
+func FuzzDataPolyRoundtrip(f *testing.F) {
+	f.Add(randBytes1, uint16(0), uint8(0), uint16(0))
+	f.Add(bytes.Repeat([]byte{0xff}, internal.SecretSize), uint16(internal.DateMask), uint8(internal.FeatureMask), uint16(internal.GfMask))
+	f.Add([]byte{0x80, 0x01}, uint16(0x155), uint8(0x0a), uint16(0x2aa))
+
+	f.Fuzz(func(t *testing.T, secret []byte, birthday uint16, features uint8, checksum uint16) {
+		d := &internal.Data{
+			Birthday: birthday & internal.DateMask,
+			Features: features & internal.FeatureMask,
+			Checksum: checksum & internal.GfMask,
+		}
+		copy(d.Secret[:internal.SecretSize], secret)
+		d.Secret[internal.SecretSize-1] &= internal.ClearMask
+
+		p := &internal.GfPoly{}
+		p.Coeff[0] = internal.GfElem(d.Checksum)
+		internal.DataToPoly(d, p)
+
+		for i, c := range p.Coeff {
+			if c > internal.GfMask {
+				t.Fatalf("coefficient %d = %d is out of range", i, c)
+			}
+		}
+
+		out := &internal.Data{}
+		internal.PolyToData(p, out)
+		if *out != *d {
+			t.Fatalf("roundtrip mismatch:\ninput:  %+v\noutput: %+v", *d, *out)
+		}
+	})
+}
+
+func TestMemzero(t *testing.T) {
+	buf := bytes.Repeat([]byte{0xff}, 64)
+	memzero(buf)
+	if !bytes.Equal(buf, make([]byte, 64)) {
+		t.Error("memzero left data in the buffer")
+	}
+
+	p := &internal.GfPoly{}
+	for i := range p.Coeff {
+		p.Coeff[i] = internal.GfMask
+	}
+	wipePoly(p)
+	if *p != (internal.GfPoly{}) {
+		t.Error("wipePoly left data in the polynomial")
+	}
+
+	seed := newTestSeed(t)
+	seed.Free()
+	if seed.secret != [32]byte{} {
+		t.Error("Free left data in the secret")
+	}
+}
+
+func TestAutoWipe(t *testing.T) {
+	SetAutoWipe(true)
+	defer SetAutoWipe(false)
+
+	// Seeds from every constructor can be collected with the finalizer set
+	for i := 0; i < 16; i++ {
+		seed, err := CreateWithBirthday(randBytes1, seedTime1, 0)
+		if err != nil {
+			t.Fatalf("CreateWithBirthday failed: %v", err)
+		}
+		clone := seed.Clone()
+		decoded, _, err := Decode(expectedPhraseEn1, CoinMonero)
+		if err != nil {
+			t.Fatalf("Decode failed: %v", err)
+		}
+		if !clone.Equal(decoded) {
+			t.Fatal("decoded seed does not match")
+		}
+		// An explicit Free before the finalizer runs is harmless
+		seed.Free()
+	}
+
+	// The finalizer wipes the seed
+	seed := newTestSeed(t)
+	finalizeSeed(seed)
+	if seed.secret != [32]byte{} {
+		t.Error("finalizeSeed did not wipe the seed")
+	}
+
+	// The finalizer runs once the seed is unreachable. The birthday tells
+	// the seed apart from the ones above, whose finalizers may run as well.
+	const targetTime = seedTime1 + 365*24*60*60
+	target := birthdayEncode(targetTime)
+	finalized := make(chan struct{}, 1)
+	hook := func(s *Seed) {
+		if s.birthday == target {
+			select {
+			case finalized <- struct{}{}:
+			default:
+			}
+		}
+	}
+	finalizeHook.Store(&hook)
+	defer finalizeHook.Store(nil)
+
+	func() {
+		seed, err := CreateWithBirthday(randBytes1, targetTime, 0)
+		if err != nil {
+			t.Fatalf("CreateWithBirthday failed: %v", err)
+		}
+		if seed.birthday != target || target == birthdayEncode(seedTime1) {
+			t.Fatal("target seed is not distinguishable")
+		}
+	}()
+
+	deadline := time.After(5 * time.Second)
+	for {
+		runtime.GC()
+		select {
+		case <-finalized:
+			return
+		case <-deadline:
+			t.Fatal("finalizer did not run")
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+}
+
+func TestDiagnoseChecksum(t *testing.T) {
+	langEn := lang.GetLangByName("English")
+	words := strings.Fields(expectedPhraseEn1)
+
+	vectors := []struct {
+		index int
+		typo  string
+	}{
+		{1, "rail"}, // position of the coin
+		{2, "wear"},
+		{4, "brief"},
+		{7, "damp"},
+	}
+	for _, v := range vectors {
+		bad := append([]string(nil), words...)
+		bad[v.index] = v.typo
+		index, corrected, ok := DiagnoseChecksum(bad, langEn, CoinMonero)
+		if !ok || index != v.index || corrected != words[v.index] {
+			t.Errorf("DiagnoseChecksum(%q at %d) = %d, %q, %v; expected %d, %q",
+				v.typo, v.index, index, corrected, ok, v.index, words[v.index])
+		}
+	}
+
+	// Valid phrase, unknown word and wrong word count
+	if _, _, ok := DiagnoseChecksum(words, langEn, CoinMonero); ok {
+		t.Error("DiagnoseChecksum reported an error in a valid phrase")
+	}
+	bad := append([]string(nil), words...)
+	bad[3] = "xyzzy"
+	if _, _, ok := DiagnoseChecksum(bad, langEn, CoinMonero); ok {
+		t.Error("DiagnoseChecksum accepted an unknown word")
+	}
+	if _, _, ok := DiagnoseChecksum(words[:15], langEn, CoinMonero); ok {
+		t.Error("DiagnoseChecksum accepted 15 words")
+	}
+}
+
+func TestPhraseToPolynomial(t *testing.T) {
+	langEn := lang.GetLangByName("English")
+	words := strings.Fields(expectedPhraseEn1)
+
+	coeffs, err := PhraseToPolynomial(words, langEn, CoinMonero)
+	if err != nil {
+		t.Fatalf("PhraseToPolynomial failed: %v", err)
+	}
+	for i, word := range words {
+		if int(coeffs[i]) != langEn.FindWord(word) {
+			t.Errorf("coefficient %d = %d, expected index of %q", i, coeffs[i], word)
+		}
+	}
+	if !PolynomialChecksumOK(coeffs) {
+		t.Error("checksum of a valid phrase does not match")
+	}
+
+	// The coin is part of the polynomial
+	aeon, err := PhraseToPolynomial(words, langEn, CoinAeon)
+	if err != nil {
+		t.Fatalf("PhraseToPolynomial failed: %v", err)
+	}
+	if aeon[1] != coeffs[1]^uint16(CoinAeon) || PolynomialChecksumOK(aeon) {
+		t.Error("coin is not applied to coefficient 1")
+	}
+
+	coeffs[5] ^= 1
+	if PolynomialChecksumOK(coeffs) {
+		t.Error("checksum matches after a change")
+	}
+	coeffs[5] ^= 1
+	coeffs[0] |= internal.GfSize
+	if PolynomialChecksumOK(coeffs) {
+		t.Error("checksum matches with an out of range coefficient")
+	}
+
+	if _, err := PhraseToPolynomial(words[:15], langEn, CoinMonero); !errors.Is(err, StatusErrNumWords) {
+		t.Errorf("15 words: expected StatusErrNumWords, got %v", err)
+	}
+	bad := append([]string(nil), words...)
+	bad[3] = "xyzzy"
+	if _, err := PhraseToPolynomial(bad, langEn, CoinMonero); err != StatusErrLang {
+		t.Errorf("unknown word: expected StatusErrLang, got %v", err)
+	}
+}
+
+func BenchmarkPhraseDecode(b *testing.B) {
+	phrases := []struct {
+		name   string
+		phrase string
+	}{
+		{"English", expectedPhraseEn1},
+		{"French", expectedPhraseFr1},
+		{"ChineseSimplified", expectedPhraseZhS1},
+	}
+	for _, v := range phrases {
+		words := SplitPhrase(v.phrase)
+		b.Run(v.name, func(b *testing.B) {
+			for b.Loop() {
+				if _, _, err := lang.PhraseDecode(words); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func TestFindWordAllWords(t *testing.T) {
+	for i := 0; i < GetNumLangs(); i++ {
+		l := GetLang(i)
+		for j, word := range l.Words {
+			if idx := l.FindWord(word); idx != j {
+				t.Errorf("%s: FindWord(%q) = %d, expected %d", l.NameEn, word, idx, j)
+			}
+		}
+		if idx := l.FindWord("xyzzy"); idx != -1 {
+			t.Errorf("%s: FindWord(\"xyzzy\") = %d, expected -1", l.NameEn, idx)
+		}
+	}
+	// Unregistered languages are searched without the index
+	unregistered := *lang.GetLangByName("French")
+	for _, word := range []string{"sincère", "sincere", UTF8NFKDLazy("sincère")} {
+		if idx := unregistered.FindWord(UTF8NFKDLazy(word)); idx < 0 || unregistered.Words[idx] != UTF8NFKDLazy("sincère") {
+			t.Errorf("unregistered: FindWord(%q) = %d", word, idx)
+		}
+	}
+	if idx := unregistered.FindWord("xyzzy"); idx != -1 {
+		t.Errorf("unregistered: FindWord(\"xyzzy\") = %d, expected -1", idx)
+	}
+}
+
+func BenchmarkFindClosest(b *testing.B) {
+	langFr := lang.GetLangByName("French")
+	for b.Loop() {
+		if _, _, ok := langFr.FindClosest("sinsère", 2); !ok {
+			b.Fatal("no match")
+		}
+	}
+}
+
+func BenchmarkDecode(b *testing.B) {
+	b.ReportAllocs()
+	for b.Loop() {
+		seed, _, err := Decode(expectedPhraseEn1, CoinMonero)
+		if err != nil {
+			b.Fatal(err)
+		}
+		seed.Free()
+	}
+}
+
+func BenchmarkLoad(b *testing.B) {
+	seed, err := CreateWithBirthday(randBytes1, seedTime1, 0)
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer seed.Free()
+	var storage Storage
+	seed.Store(&storage)
+
+	b.ReportAllocs()
+	for b.Loop() {
+		loaded, err := Load(&storage)
+		if err != nil {
+			b.Fatal(err)
+		}
+		loaded.Free()
+	}
+}
+
+func TestPoolWiped(t *testing.T) {
+	seed := newTestSeed(t)
+	var storage Storage
+	seed.Store(&storage)
+
+	// Use every pooled code path, including the error paths
+	for i := 0; i < 8; i++ {
+		if decoded, _, err := Decode(expectedPhraseEn1, CoinMonero); err == nil {
+			decoded.Free()
+		}
+		ValidatePhrase(expectedPhraseEn1, CoinAeon)
+		if loaded, err := Load(&storage); err == nil {
+			loaded.Free()
+		}
+		corrupt := storage
+		corrupt[20] ^= 1
+		corrupt.Validate()
+		seed.KeygenAccount(CoinMonero, 1, 8)
+	}
+
+	for i := 0; i < 16; i++ {
+		if p := getPoly(); *p != (internal.GfPoly{}) {
+			t.Fatal("pooled polynomial is not wiped")
+		}
+		if d := getData(); *d != (internal.Data{}) {
+			t.Fatal("pooled data is not wiped")
+		}
+	}
+}
+
+func TestEntropy(t *testing.T) {
+	seed := newTestSeed(t)
+
+	entropy := seed.Entropy()
+	if len(entropy) != internal.SecretSize {
+		t.Fatalf("Entropy length = %d, expected %d", len(entropy), internal.SecretSize)
+	}
+	if entropy[internal.SecretSize-1]&^internal.ClearMask != 0 {
+		t.Error("unused bits of the entropy are set")
+	}
+	expected := bytes.Clone(randBytes1[:internal.SecretSize])
+	expected[internal.SecretSize-1] &= internal.ClearMask
+	if !bytes.Equal(entropy, expected) {
+		t.Errorf("Entropy = %x, expected %x", entropy, expected)
+	}
+
+	recreated, err := CreateWithBirthday(entropy, seed.GetBirthday(), 0)
+	if err != nil {
+		t.Fatalf("CreateWithBirthday failed: %v", err)
+	}
+	defer recreated.Free()
+	if !recreated.Equal(seed) {
+		t.Error("seed recreated from its entropy does not match")
+	}
+
+	// The result is a copy
+	entropy[0] ^= 0xff
+	if bytes.Equal(seed.Entropy(), entropy) {
+		t.Error("Entropy returned the internal buffer")
+	}
+}
+
+func TestFingerprint(t *testing.T) {
+	seed := newTestSeed(t)
+
+	const expectedFingerprint1 = "801866b6"
+	if fp := seed.Fingerprint(); fp != expectedFingerprint1 {
+		t.Errorf("Fingerprint = %q, expected %q", fp, expectedFingerprint1)
+	}
+
+	decoded, _, err := Decode(expectedPhraseEn1, CoinMonero)
+	if err != nil {
+		t.Fatalf("Decode failed: %v", err)
+	}
+	defer decoded.Free()
+	if fp := decoded.Fingerprint(); fp != expectedFingerprint1 {
+		t.Errorf("Fingerprint after decoding = %q, expected %q", fp, expectedFingerprint1)
+	}
+
+	// A different birthday changes the fingerprint
+	other, err := CreateWithBirthday(randBytes1, seedTime2, 0)
+	if err != nil {
+		t.Fatalf("CreateWithBirthday failed: %v", err)
+	}
+	defer other.Free()
+	if other.Fingerprint() == expectedFingerprint1 {
+		t.Error("seeds with different birthdays have the same fingerprint")
+	}
+}
+
+func TestCompactBytes(t *testing.T) {
+	for _, v := range []struct {
+		timestamp uint64
+		features  uint8
+	}{
+		{seedTime1, 0},
+		{seedTime2, 0},
+		{seedTime3, 0},
+	} {
+		seed, err := CreateWithBirthday(randBytes1, v.timestamp, v.features)
+		if err != nil {
+			t.Fatalf("CreateWithBirthday failed: %v", err)
+		}
+		defer seed.Free()
+
+		compact := seed.CompactBytes()
+		if len(compact) != CompactSize {
+			t.Fatalf("CompactBytes length = %d, expected %d", len(compact), CompactSize)
+		}
+		loaded, err := LoadCompact(compact)
+		if err != nil {
+			t.Fatalf("LoadCompact failed: %v", err)
+		}
+		defer loaded.Free()
+		if !loaded.Equal(seed) {
+			t.Errorf("compact roundtrip does not match for timestamp %d", v.timestamp)
+		}
+	}
+
+	// An encrypted seed keeps its encryption flag
+	seed, err := CreateWithBirthday(randBytes2, seedTime2, 0)
+	if err != nil {
+		t.Fatalf("CreateWithBirthday failed: %v", err)
+	}
+	defer seed.Free()
+	if err := seed.Crypt("password"); err != nil {
+		t.Fatalf("Crypt failed: %v", err)
+	}
+	compact := seed.CompactBytes()
+	loaded, err := LoadCompact(compact)
+	if err != nil || !loaded.Equal(seed) || !loaded.IsEncrypted() {
+		t.Errorf("encrypted compact roundtrip failed: %v", err)
+	}
+
+	// Every bit is significant
+	for bit := 0; bit < 8*CompactSize; bit++ {
+		corrupt := bytes.Clone(compact)
+		corrupt[bit/8] ^= 0x80 >> (bit % 8)
+		if loaded, err := LoadCompact(corrupt); err == nil && loaded.Equal(seed) {
+			t.Errorf("flipping bit %d has no effect", bit)
+		}
+	}
+
+	if _, err := LoadCompact(compact[:CompactSize-1]); err != StatusErrFormat {
+		t.Errorf("short input: expected StatusErrFormat, got %v", err)
+	}
+}
+
+func TestPhrasesEquivalent(t *testing.T) {
+	seed := newTestSeed(t)
+	phraseFr := seed.Encode(lang.GetLangByName("French"), CoinMonero)
+
+	vectors := []struct {
+		a, b     string
+		expected bool
+	}{
+		{expectedPhraseEn1, expectedPhraseEn1, true},
+		{expectedPhraseEn1, "  " + strings.ReplaceAll(expectedPhraseEn1, " ", "\t") + "\n", true},
+		{expectedPhraseEs1, expectedPhraseEs2, true},
+		{expectedPhraseFr1, expectedPhraseFr2, true},
+		{norm.NFC.String(expectedPhraseFr1), norm.NFD.String(expectedPhraseFr1), true},
+		{expectedPhraseEn1, phraseFr, true},
+		{expectedPhraseEn1, expectedPhraseEn2, true},
+		{expectedPhraseEs1, expectedPhraseEs3, true},
+		{expectedPhraseEn1, expectedPhraseEs1, false},
+	}
+	for _, v := range vectors {
+		equivalent, err := PhrasesEquivalent(v.a, v.b, CoinMonero)
+		if err != nil || equivalent != v.expected {
+			t.Errorf("PhrasesEquivalent(%q, %q) = %v, %v; expected %v", v.a, v.b, equivalent, err, v.expected)
+		}
+	}
+
+	if _, err := PhrasesEquivalent(expectedPhraseEn1, "raven tail", CoinMonero); !errors.Is(err, StatusErrNumWords) {
+		t.Errorf("short phrase: expected StatusErrNumWords, got %v", err)
+	}
+}
+
+func TestPrefixTruncation(t *testing.T) {
+	// truncate cuts a word to its first characters as a user would type
+	// them, counting precomposed accented letters as one character
+	truncate := func(word string) string {
+		runes := []rune(norm.NFC.String(word))
+		if len(runes) > 4 {
+			runes = runes[:4]
+		}
+		return string(runes)
+	}
+
+	for _, phrase := range []string{expectedPhraseEn2, expectedPhraseEs3} {
+		if _, _, err := Decode(phrase, CoinMonero); err != nil {
+			t.Errorf("Decode(%q) failed: %v", phrase, err)
+		}
+	}
+
+	seed := newTestSeed(t)
+
+	for i := 0; i < GetNumLangs(); i++ {
+		l := GetLang(i)
+		if !l.HasPrefix {
+			continue
+		}
+		t.Run(l.NameEn, func(t *testing.T) {
+			for j, word := range l.Words {
+				short := truncate(word)
+				if idx := l.FindWord(UTF8NFKDLazy(short)); idx != j {
+					t.Errorf("FindWord(%q) = %d, expected %d (%q)", short, idx, j, word)
+				}
+			}
+
+			words := strings.Fields(seed.Encode(l, CoinMonero))
+			for j := range words {
+				words[j] = truncate(words[j])
+			}
+			phrase := strings.Join(words, " ")
+			decoded, foundLang, err := Decode(phrase, CoinMonero)
+			if err != nil {
+				t.Fatalf("Decode(%q) failed: %v", phrase, err)
+			}
+			defer decoded.Free()
+			if foundLang != l || !decoded.Equal(seed) {
+				t.Errorf("Decode(%q) returned a different seed or language %s", phrase, foundLang.NameEn)
+			}
+		})
+	}
+
+	// Unregistered languages use the same matching rules
+	unregistered := *lang.GetLangByName("Spanish")
+	if idx := unregistered.FindWord(UTF8NFKDLazy("céle")); idx < 0 || truncate(unregistered.Words[idx]) != "céle" {
+		t.Errorf("unregistered: FindWord(%q) = %d", "céle", idx)
+	}
+
+	// A prefix must be long enough, and a full word cannot be extended
+	langEn := lang.GetLangByName("English")
+	for _, word := range []string{"rav", "ravens", "ravx"} {
+		if idx := langEn.FindWord(word); idx >= 0 {
+			t.Errorf("FindWord(%q) = %d (%q), expected no match", word, idx, langEn.Words[idx])
+		}
+	}
+}
+
+func TestWordList(t *testing.T) {
+	langEn := lang.GetLangByName("English")
+	words := langEn.WordList()
+	if len(words) != lang.LangSize {
+		t.Fatalf("WordList length = %d, expected %d", len(words), lang.LangSize)
+	}
+	for i, word := range words {
+		if w, ok := langEn.Word(i); !ok || w != word {
+			t.Fatalf("Word(%d) = %q, %v; expected %q", i, w, ok, word)
+		}
+	}
+	if words[0] != "abandon" || words[lang.LangSize-1] != "zoo" {
+		t.Errorf("unexpected first or last word: %q, %q", words[0], words[lang.LangSize-1])
+	}
+
+	// The list is a copy
+	words[0] = "changed"
+	if langEn.Words[0] != "abandon" {
+		t.Error("WordList returned the internal array")
+	}
+
+	for _, i := range []int{-1, lang.LangSize} {
+		if w, ok := langEn.Word(i); ok || w != "" {
+			t.Errorf("Word(%d) = %q, %v; expected out of range", i, w, ok)
+		}
+	}
+}
+
+func TestVerifyWordlists(t *testing.T) {
+	for i := 0; i < GetNumLangs(); i++ {
+		l := GetLang(i)
+		if err := l.Verify(); err != nil {
+			t.Errorf("%s: %v", l.NameEn, err)
+		}
+	}
+
+	langEn := lang.GetLangByName("English")
+	broken := *langEn
+	broken.Words[10], broken.Words[11] = broken.Words[11], broken.Words[10]
+	if err := broken.Verify(); !errors.Is(err, lang.ErrWordlist) {
+		t.Errorf("unsorted words: expected ErrWordlist, got %v", err)
+	}
+	broken.IsSorted = false
+	if err := broken.Verify(); err != nil {
+		t.Errorf("unsorted words without IsSorted: %v", err)
+	}
+
+	broken = *lang.GetLangByName("French")
+	broken.IsSorted = false
+	broken.Words[5] = norm.NFC.String("élève")
+	if err := broken.Verify(); !errors.Is(err, lang.ErrWordlist) {
+		t.Errorf("NFC word: expected ErrWordlist, got %v", err)
+	}
+}
+
+func TestMust(t *testing.T) {
+	seed := MustCreate(0)
+	seed.Free()
+
+	decoded, foundLang := MustDecode(expectedPhraseEn1, CoinMonero)
+	defer decoded.Free()
+	if foundLang != lang.GetLangByName("English") {
+		t.Errorf("MustDecode detected %v", foundLang)
+	}
+
+	mustPanic := func(name string, f func()) {
+		t.Helper()
+		defer func() {
+			if r := recover(); r == nil {
+				t.Errorf("%s did not panic", name)
+			}
+		}()
+		f()
+	}
+	mustPanic("MustCreate", func() { MustCreate(1) })
+	mustPanic("MustDecode", func() { MustDecode("raven tail", CoinMonero) })
+}
+
+func TestStatusUnwrap(t *testing.T) {
+	words := strings.Fields(expectedPhraseEn1)
+	words[3] = "xyzzy"
+	_, _, err := Decode(strings.Join(words, " "), CoinMonero)
+	if err != StatusErrLang {
+		t.Fatalf("expected StatusErrLang, got %v", err)
+	}
+	if !errors.Is(err, lang.ErrLang) || errors.Is(err, lang.ErrMultLang) {
+		t.Errorf("unknown word: errors.Is does not match lang.ErrLang only")
+	}
+
+	var err2 error = StatusErrMultLang
+	if !errors.Is(err2, StatusErrMultLang) || !errors.Is(err2, lang.ErrMultLang) || errors.Is(err2, lang.ErrLang) {
+		t.Errorf("StatusErrMultLang: errors.Is does not match lang.ErrMultLang only")
+	}
+	var status Status
+	if !errors.As(fmt.Errorf("decode: %w", err2), &status) || status != StatusErrMultLang {
+		t.Errorf("errors.As = %v", status)
+	}
+
+	if langError(lang.ErrMultLang) != StatusErrMultLang || langError(lang.ErrLang) != StatusErrLang {
+		t.Errorf("langError does not translate lang errors")
+	}
+	if StatusErrChecksum.Unwrap() != nil {
+		t.Errorf("StatusErrChecksum has a cause")
+	}
+}
+
+func TestLanguages(t *testing.T) {
+	langs := Languages()
+	if len(langs) != LanguageCount() || LanguageCount() != GetNumLangs() {
+		t.Fatalf("Languages() has %d entries, LanguageCount() = %d", len(langs), LanguageCount())
+	}
+	for i, l := range langs {
+		if l != GetLang(i) {
+			t.Errorf("Languages()[%d] = %v, expected %v", i, l, GetLang(i))
+		}
+	}
+	if langs[0] != lang.GetLangByName("English") {
+		t.Errorf("Languages()[0] is not English")
+	}
+
+	langs[0] = nil
+	if Languages()[0] == nil {
+		t.Errorf("Languages() does not return a copy")
+	}
+}
+
+func TestNumWordsError(t *testing.T) {
+	words := strings.Fields(expectedPhraseEn1)
+	for _, n := range []int{15, 17} {
+		phrase := strings.Join(append(words[:15:15], words[:n-15]...), " ")
+		_, _, err := Decode(phrase, CoinMonero)
+		var numErr *NumWordsError
+		if !errors.As(err, &numErr) || numErr.Got != n || numErr.Expected != NumWords {
+			t.Errorf("%d words: unexpected error %v", n, err)
+		}
+		if !errors.Is(err, StatusErrNumWords) {
+			t.Errorf("%d words: error does not match StatusErrNumWords", n)
+		}
+		if _, err := DecodeExplicit(phrase, CoinMonero, lang.GetLangByName("English")); !errors.As(err, &numErr) || numErr.Got != n {
+			t.Errorf("DecodeExplicit with %d words: unexpected error %v", n, err)
+		}
+	}
+
+	var numErr *NumWordsError
+	_, err := RecoverChecksumWord(words[:10], CoinMonero, lang.GetLangByName("English"))
+	if !errors.As(err, &numErr) || numErr.Got != 10 || numErr.Expected != NumWords-1 {
+		t.Errorf("RecoverChecksumWord: unexpected error %v", err)
+	}
+	if msg := err.Error(); msg != "wrong number of words in the phrase: got 10, expected 15" {
+		t.Errorf("Unexpected message %q", msg)
+	}
+}
+
+func TestVerifyTestVectors(t *testing.T) {
+	if err := VerifyTestVectors(); err != nil {
+		t.Fatalf("VerifyTestVectors failed: %v", err)
+	}
+
+	vectors := TestVectors()
+	if vectors[0].Phrase != expectedPhraseEn1 || !bytes.Equal(vectors[0].Entropy, randBytes1) {
+		t.Errorf("Unexpected first vector: %+v", vectors[0])
+	}
+	phrases := make(map[string]bool)
+	for _, v := range vectors {
+		phrases[v.Phrase] = true
+	}
+	for _, phrase := range []string{expectedPhraseEn1, expectedPhraseEs1, expectedPhraseFr1, expectedPhraseZhS1, expectedPhraseIt1, expectedPhrasePt1, expectedPhraseCs1, expectedPhraseKo1, expectedPhraseZhT1} {
+		if !phrases[phrase] {
+			t.Errorf("Missing vector for %q", phrase)
+		}
+	}
+
+	vectors[0].Entropy[0] ^= 1
+	if TestVectors()[0].Entropy[0] != randBytes1[0] {
+		t.Error("TestVectors does not return a copy")
+	}
+	if err := verifyTestVectors(vectors); !errors.Is(err, ErrTestVector) {
+		t.Errorf("modified entropy: expected ErrTestVector, got %v", err)
+	}
+
+	vectors = TestVectors()
+	vectors[1].Key = vectors[len(vectors)-1].Key
+	if err := verifyTestVectors(vectors); !errors.Is(err, ErrTestVector) || !strings.Contains(err.Error(), "vector 1") {
+		t.Errorf("modified key: expected ErrTestVector for vector 1, got %v", err)
+	}
+}
+
+func TestDecodeBatch(t *testing.T) {
+	words := strings.Fields(expectedPhraseEn1)
+	input := expectedPhraseEn1 + "\n" +
+		"\n" +
+		strings.Join(words[1:], " ") + "\r\n" +
+		"   \t\n" +
+		expectedPhraseEs1 + "\r\n" +
+		strings.Join(append([]string{"zoo"}, words[1:]...), " ") + "\n" +
+		expectedPhraseFr1
+
+	results, err := DecodeBatch(strings.NewReader(input), CoinMonero)
+	if err != nil {
+		t.Fatalf("DecodeBatch failed: %v", err)
+	}
+	expected := []struct {
+		line     int
+		nameEn   string
+		expected error
+	}{
+		{1, "English", nil},
+		{3, "", StatusErrNumWords},
+		{5, "Spanish", nil},
+		{6, "", StatusErrChecksum},
+		{7, "French", nil},
+	}
+	if len(results) != len(expected) {
+		t.Fatalf("Expected %d results, got %d", len(expected), len(results))
+	}
+	for i, r := range results {
+		e := expected[i]
+		if r.Line != e.line || !errors.Is(r.Err, e.expected) || (r.Err == nil) != (r.Seed != nil) {
+			t.Errorf("Result %d: %+v", i, r)
+		}
+		if e.nameEn != "" && r.Lang != lang.GetLangByName(e.nameEn) {
+			t.Errorf("Result %d: expected %s, got %v", i, e.nameEn, r.Lang)
+		}
+		if r.Seed != nil {
+			r.Seed.Free()
+		}
+	}
+
+	// An over-long line is reported without stopping the batch
+	input = expectedPhraseEn1 + "\n" +
+		strings.Repeat("x", 3*batchMaxLine) + "\n" +
+		expectedPhraseEs1
+	results, err = DecodeBatch(strings.NewReader(input), CoinMonero)
+	if err != nil || len(results) != 3 {
+		t.Fatalf("Expected 3 results, got %v, %v", results, err)
+	}
+	if results[1].Line != 2 || results[1].Err != ErrLineTooLong || results[1].Seed != nil {
+		t.Errorf("Expected ErrLineTooLong on line 2, got %+v", results[1])
+	}
+	if results[2].Line != 3 || results[2].Err != nil || results[2].Lang != lang.GetLangByName("Spanish") {
+		t.Errorf("Expected the Spanish phrase on line 3, got %+v", results[2])
+	}
+	results[0].Seed.Free()
+	results[2].Seed.Free()
+
+	readErr := errors.New("read failed")
+	results, err = DecodeBatch(io.MultiReader(strings.NewReader(expectedPhraseEn1+"\n"), iotest.ErrReader(readErr)), CoinMonero)
+	if err != readErr || len(results) != 1 || results[0].Err != nil {
+		t.Errorf("Expected one result and the read error, got %v, %v", results, err)
+	}
+	for _, r := range results {
+		r.Seed.Free()
+	}
+}
+
+func TestEnableFeaturesChecked(t *testing.T) {
+	defer EnableFeatures(0)
+
+	if err := EnableFeaturesChecked(3); err != nil {
+		t.Fatalf("EnableFeaturesChecked(3) failed: %v", err)
+	}
+	if !featuresSupported(3) || featuresSupported(4) {
+		t.Error("Unexpected enabled features after EnableFeaturesChecked(3)")
+	}
+
+	for _, mask := range []uint8{8, 0x13, encryptedMask, 0xFF} {
+		err := EnableFeaturesChecked(mask)
+		if !errors.Is(err, StatusErrUnsupported) {
+			t.Errorf("EnableFeaturesChecked(%#x): expected StatusErrUnsupported, got %v", mask, err)
+		}
+	}
+	if !featuresSupported(3) || featuresSupported(4) {
+		t.Error("A rejected mask changed the enabled features")
+	}
+}
+
+func TestSeedValidate(t *testing.T) {
+	seed := newTestSeed(t)
+	if err := seed.Validate(); err != nil {
+		t.Fatalf("Validate failed for a valid seed: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		modify   func(s *Seed)
+		expected error
+	}{
+		{"Checksum", func(s *Seed) { s.checksum ^= 1 }, StatusErrChecksum},
+		{"ChecksumRange", func(s *Seed) { s.checksum |= 0x800 }, StatusErrFormat},
+		{"Secret", func(s *Seed) { s.secret[3] ^= 1 }, StatusErrChecksum},
+		{"ClearBits", func(s *Seed) { s.secret[internal.SecretSize-1] |= 0x80 }, StatusErrFormat},
+		{"UnusedSecret", func(s *Seed) { s.secret[internal.SecretSize] = 1 }, StatusErrFormat},
+		{"BirthdayRange", func(s *Seed) { s.birthday = DateMask + 1 }, StatusErrFormat},
+		{"FeaturesRange", func(s *Seed) { s.features = FeatureMask + 1 }, StatusErrFormat},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			broken := seed.Clone()
+			defer broken.Free()
+			tt.modify(broken)
+			if err := broken.Validate(); err != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, err)
+			}
+		})
+	}
+
+	// Features are checked against the enabled ones
+	EnableFeatures(1)
+	featured := seed.Clone()
+	defer featured.Free()
+	err := featured.SetFeature(1, true)
+	EnableFeatures(0)
+	if err != nil {
+		t.Fatalf("SetFeature failed: %v", err)
+	}
+	if err := featured.Validate(); err != StatusErrUnsupported {
+		t.Errorf("Expected StatusErrUnsupported, got %v", err)
+	}
+
+	// Encryption keeps the seed consistent
+	if err := seed.Crypt("password"); err != nil {
+		t.Fatalf("Crypt failed: %v", err)
+	}
+	if err := seed.Validate(); err != nil {
+		t.Errorf("Validate failed for an encrypted seed: %v", err)
+	}
+}
+
+func TestSeedData(t *testing.T) {
+	seed := newTestSeed(t)
+
+	d := seed.Data()
+	if d.Birthday != EncodeBirthday(seedTime1) || d.Features != 0 || d.Checksum != seed.Checksum() {
+		t.Errorf("Unexpected seed data: birthday %d, features %d, checksum %d", d.Birthday, d.Features, d.Checksum)
+	}
+	if !bytes.Equal(d.Secret[:internal.SecretSize], seed.Secret()) || !bytes.Equal(d.Secret[internal.SecretSize:], make([]byte, 32-internal.SecretSize)) {
+		t.Errorf("Unexpected secret %x", d.Secret)
+	}
+
+	restored, err := SeedDataToSeed(d)
+	if err != nil {
+		t.Fatalf("SeedDataToSeed failed: %v", err)
+	}
+	defer restored.Free()
+	if !restored.Equal(seed) || restored.Encode(lang.GetLangByName("English"), CoinMonero) != expectedPhraseEn1 {
+		t.Error("SeedDataToSeed roundtrip failed")
+	}
+
+	d.Secret[0] ^= 1
+	if _, err := SeedDataToSeed(d); err != StatusErrChecksum {
+		t.Errorf("Expected StatusErrChecksum, got %v", err)
+	}
+	d.Secret[0] ^= 1
+	d.Birthday = DateMask + 1
+	if _, err := SeedDataToSeed(d); err != StatusErrFormat {
+		t.Errorf("Expected StatusErrFormat, got %v", err)
+	}
+}
+
+func TestEncryptionScheme(t *testing.T) {
+	seed := newTestSeed(t)
+
+	if scheme, encrypted := seed.EncryptionScheme(); scheme != EncryptionNone || encrypted {
+		t.Errorf("Unencrypted seed: got %d, %v", scheme, encrypted)
+	}
+	if err := seed.Encrypt("password"); err != nil {
+		t.Fatalf("Encrypt failed: %v", err)
+	}
+	if scheme, encrypted := seed.EncryptionScheme(); scheme != EncryptionPassword || !encrypted {
+		t.Errorf("Encrypted seed: got %d, %v", scheme, encrypted)
+	}
+
+	// The reserved internal bit is an unknown scheme, with or without the
+	// passphrase bit
+	for _, features := range []uint8{8, 8 | encryptedMask, 8 | 7} {
+		unknown := seed.Clone()
+		unknown.features = features
+		if scheme, encrypted := unknown.EncryptionScheme(); scheme != EncryptionUnknown || !encrypted {
+			t.Errorf("Features %#x: got %d, %v", features, scheme, encrypted)
+		}
+		unknown.Free()
+	}
+}
+
+func TestSeedString(t *testing.T) {
+	seed := newTestSeed(t)
+
+	const expected = "Seed{birthday: 2021-12, features: 0x0, encrypted: false, fingerprint: 801866b6}"
+	snapshot := seed.Snapshot()
+	outputs := map[string]string{
+		"%v":           fmt.Sprintf("%v", seed),
+		"%s":           fmt.Sprintf("%s", seed),
+		"%+v":          fmt.Sprintf("%+v", seed),
+		"%#v":          fmt.Sprintf("%#v", seed),
+		"Snapshot %v":  fmt.Sprintf("%v", snapshot),
+		"Snapshot %#v": fmt.Sprintf("%#v", snapshot),
+		"Slice %v":     fmt.Sprintf("%v", []*Seed{seed}),
+	}
+	secret := seed.Secret()
+	for name, out := range outputs {
+		if !strings.Contains(out, expected) {
+			t.Errorf("%s: unexpected output %q", name, out)
+		}
+		if strings.Contains(out, fmt.Sprint(secret[0])+" ") || strings.Contains(out, hex.EncodeToString(secret)) {
+			t.Errorf("%s: output contains the secret: %q", name, out)
+		}
+	}
+	if out := fmt.Sprintf("%#v", seed); out != "polyseed."+expected {
+		t.Errorf("Unexpected GoString %q", out)
+	}
+
+	if err := seed.Crypt("password"); err != nil {
+		t.Fatalf("Crypt failed: %v", err)
+	}
+	if out := seed.String(); !strings.Contains(out, "encrypted: true") {
+		t.Errorf("Unexpected output for an encrypted seed: %q", out)
+	}
+}
+
+func TestDiffWordlists(t *testing.T) {
+	langEs := lang.GetLangByName("Spanish")
+	if diffs := lang.DiffWordlists(langEs, langEs); len(diffs) != 0 {
+		t.Errorf("Expected no differences, got %d", len(diffs))
+	}
+
+	celebre := langEs.FindWord(NormalizePhrase("célebre"))
+	sonoro := langEs.FindWord("sonoro")
+	variant := *langEs
+	variant.NameEn = "Spanish (variant)"
+	variant.Words[celebre] = "celebre"
+	variant.Words[celebre+1] = langEs.Words[celebre+1][:4]
+	variant.Words[sonoro] = "sonorox"
+	variant.Words[7] = "zzz"
+
+	diffs := lang.DiffWordlists(langEs, &variant)
+	expected := []lang.WordDiff{
+		{Index: 7, A: langEs.Words[7], B: "zzz", SharedPrefix: 0, Equivalent: false},
+		{Index: celebre, A: langEs.Words[celebre], B: "celebre", SharedPrefix: 7, Equivalent: true},
+		{Index: celebre + 1, A: langEs.Words[celebre+1], B: langEs.Words[celebre+1][:4], SharedPrefix: 4, Equivalent: true},
+		{Index: sonoro, A: "sonoro", B: "sonorox", SharedPrefix: 6, Equivalent: false},
+	}
+	if len(diffs) != len(expected) {
+		t.Fatalf("Expected %d differences, got %+v", len(expected), diffs)
+	}
+	for i := range diffs {
+		if diffs[i] != expected[i] {
+			t.Errorf("Difference %d: expected %+v, got %+v", i, expected[i], diffs[i])
+		}
+	}
+
+	if diffs := lang.DiffWordlists(lang.GetLangByName("English"), lang.GetLangByName("French")); len(diffs) < lang.LangSize/2 {
+		t.Errorf("Expected most English and French words to differ, got %d", len(diffs))
+	}
+}
+
+func TestSetBirthday(t *testing.T) {
+	seed := newTestSeed(t)
+	langEn := lang.GetLangByName("English")
+	key := seed.Keygen(CoinMonero, 32)
+
+	if err := seed.SetBirthday(seedTime2); err != nil {
+		t.Fatalf("SetBirthday failed: %v", err)
+	}
+	if seed.GetBirthday() != birthdayDecode(birthdayEncode(seedTime2)) {
+		t.Errorf("Unexpected birthday %d", seed.GetBirthday())
+	}
+	if err := seed.Validate(); err != nil {
+		t.Errorf("Seed is invalid after SetBirthday: %v", err)
+	}
+	if bytes.Equal(seed.Keygen(CoinMonero, 32), key) {
+		t.Error("Key did not change with the birthday")
+	}
+
+	// Same as a seed created with the new birthday
+	expected, err := CreateWithBirthday(randBytes1, seedTime2, 0)
+	if err != nil {
+		t.Fatalf("Failed to create seed: %v", err)
+	}
+	defer expected.Free()
+	if !seed.Equal(expected) {
+		t.Error("Seed differs from one created with the new birthday")
+	}
+
+	decoded, _, err := Decode(seed.Encode(langEn, CoinMonero), CoinMonero)
+	if err != nil {
+		t.Fatalf("Failed to decode phrase: %v", err)
+	}
+	defer decoded.Free()
+	if !decoded.Equal(seed) {
+		t.Error("Roundtrip failed after SetBirthday")
+	}
+
+	// Restoring the birthday restores the phrase
+	if err := seed.SetBirthday(seedTime1); err != nil {
+		t.Fatalf("SetBirthday failed: %v", err)
+	}
+	if phrase := seed.Encode(langEn, CoinMonero); phrase != expectedPhraseEn1 {
+		t.Errorf("Unexpected phrase %q", phrase)
+	}
+
+	if err := seed.SetBirthday(seedTime3 + 100*timeStep); err != ErrBirthday {
+		t.Errorf("Expected ErrBirthday, got %v", err)
+	}
+	if phrase := seed.Encode(langEn, CoinMonero); phrase != expectedPhraseEn1 {
+		t.Error("A rejected birthday changed the seed")
+	}
+}
@@ -0,0 +1,52 @@
+// Copyright (c) 2025-2026 complex (complex@ft.hn)
+// See LICENSE for licensing information
+
+package polyseed
+
+import (
+	"sync"
+
+	"github.com/complex-gh/polyseed_go/internal"
+)
+
+// Scratch buffers that would otherwise be allocated on the heap for every
+// call. Buffers are wiped before they are returned to a pool, so pooled
+// memory never holds secrets.
+var (
+	polyPool = sync.Pool{New: func() any { return new(internal.GfPoly) }}
+	dataPool = sync.Pool{New: func() any { return new(internal.Data) }}
+)
+
+// getPoly returns a zeroed polynomial from the pool
+func getPoly() *internal.GfPoly {
+	return polyPool.Get().(*internal.GfPoly)
+}
+
+// putPoly wipes a polynomial and returns it to the pool
+func putPoly(p *internal.GfPoly) {
+	wipePoly(p)
+	polyPool.Put(p)
+}
+
+// getData returns zeroed seed data from the pool
+func getData() *internal.Data {
+	return dataPool.Get().(*internal.Data)
+}
+
+// putData wipes seed data and returns it to the pool
+func putData(d *internal.Data) {
+	memzero(d.Secret[:])
+	*d = internal.Data{}
+	dataPool.Put(d)
+}
+
+// pooledData is like toData, but returns the data in a buffer from the
+// pool that must be released with putData
+func (s *Seed) pooledData() *internal.Data {
+	d := getData()
+	d.Birthday = s.birthday
+	d.Features = s.features
+	d.Secret = s.secret
+	d.Checksum = s.checksum
+	return d
+}
@@ -0,0 +1,222 @@
+// Copyright (c) 2025-2026 complex (complex@ft.hn)
+// See LICENSE for licensing information
+
+package polyseed
+
+import (
+	"time"
+
+	"github.com/complex-gh/polyseed_go/internal"
+	"github.com/complex-gh/polyseed_go/lang"
+)
+
+// RecoverChecksumWord completes a phrase whose last word was lost.
+//
+// words are the first 15 words of the phrase in the given language. Every
+// word of a polyseed phrase is covered by the checksum, so the missing word
+// is uniquely determined by the other 15.
+//
+// Returns the complete 16-word phrase and an error if the words are not
+// valid in the language.
+func RecoverChecksumWord(words []string, coin Coin, lang *lang.Language) (string, error) {
+	if len(words) != NumWords-1 {
+		return "", numWordsError(len(words), NumWords-1)
+	}
+
+	// Look up the known words
+	p := &internal.GfPoly{}
+	for i, word := range words {
+		idx := lang.FindWord(UTF8NFKDLazy(word))
+		if idx < 0 {
+			return "", StatusErrLang
+		}
+		p.Coeff[i] = internal.GfElem(idx)
+	}
+
+	// Solve for the last word
+	p.Coeff[internal.PolyNumCheckDigits] ^= internal.GfElem(coin)
+	if !p.Solve(NumWords - 1) {
+		return "", StatusErrChecksum
+	}
+	p.Coeff[internal.PolyNumCheckDigits] ^= internal.GfElem(coin)
+
+	return polyToPhrase(p, lang, lang.Separator), nil
+}
+
+// CoefficientDistance returns the number of polynomial coefficients that
+// differ between two phrases. The language of each phrase is detected
+// independently.
+//
+// The checksum is not verified, so the phrases only need to consist of 16
+// recognizable words. This is useful to rank candidate phrases by how close
+// they are to a remembered one.
+func CoefficientDistance(a, b string, coin Coin) (int, error) {
+	pa, _, err := phraseToPoly(a, coin)
+	if err != nil {
+		return 0, err
+	}
+	defer putPoly(pa)
+	pb, _, err := phraseToPoly(b, coin)
+	if err != nil {
+		return 0, err
+	}
+	defer putPoly(pb)
+
+	dist := 0
+	for i := 0; i < NumWords; i++ {
+		if pa.Coeff[i] != pb.Coeff[i] {
+			dist++
+		}
+	}
+	return dist, nil
+}
+
+// RecoverWord finds the word missing at position missingIndex of a phrase.
+//
+// knownWords are the other 15 words of the phrase, in order. The language
+// is detected from them. Every value of the missing word is tried and the
+// ones that satisfy the checksum are returned.
+//
+// Returns the candidate words, the detected language and an error if the
+// known words are invalid, or ErrWordIndex if missingIndex is not a
+// position of the phrase.
+func RecoverWord(knownWords []string, missingIndex int, coin Coin) ([]string, *lang.Language, error) {
+	if len(knownWords) != NumWords-1 {
+		return nil, nil, numWordsError(len(knownWords), NumWords-1)
+	}
+	if missingIndex < 0 || missingIndex >= NumWords {
+		return nil, nil, ErrWordIndex
+	}
+
+	// Detect the language of the known words
+	words := make([]string, len(knownWords))
+	for i, word := range knownWords {
+		words[i] = UTF8NFKDLazy(word)
+	}
+	indices, foundLang, err := lang.PhraseDecode(words)
+	if err != nil {
+		return nil, nil, langError(err)
+	}
+
+	// Build polynomial around the missing position
+	p := &internal.GfPoly{}
+	for i, idx := range indices[:len(knownWords)] {
+		pos := i
+		if pos >= missingIndex {
+			pos++
+		}
+		p.Coeff[pos] = internal.GfElem(idx)
+	}
+
+	var candidates []string
+	for v := internal.GfElem(0); v < internal.GfSize; v++ {
+		p.Coeff[missingIndex] = v
+		p.Coeff[internal.PolyNumCheckDigits] ^= internal.GfElem(coin)
+		ok := p.Check()
+		p.Coeff[internal.PolyNumCheckDigits] ^= internal.GfElem(coin)
+		if ok {
+			candidates = append(candidates, foundLang.Words[v])
+		}
+	}
+
+	if len(candidates) == 0 {
+		return nil, nil, StatusErrChecksum
+	}
+	return candidates, foundLang, nil
+}
+
+// ChecksumWord computes the checksum word for a phrase made of 15 data
+// words chosen by the user, for example from dice rolls. The checksum word
+// is the first word of the complete phrase, followed by the data words.
+//
+// Returns the checksum word and an error if the data words are not valid
+// in the language or encode unsupported features.
+func ChecksumWord(dataWords []string, lang *lang.Language, coin Coin) (string, error) {
+	if len(dataWords) != NumWords-internal.PolyNumCheckDigits {
+		return "", numWordsError(len(dataWords), NumWords-internal.PolyNumCheckDigits)
+	}
+
+	p := &internal.GfPoly{}
+	for i, word := range dataWords {
+		idx := lang.FindWord(UTF8NFKDLazy(word))
+		if idx < 0 {
+			return "", StatusErrLang
+		}
+		p.Coeff[internal.PolyNumCheckDigits+i] = internal.GfElem(idx)
+	}
+
+	// Remove coin
+	p.Coeff[internal.PolyNumCheckDigits] ^= internal.GfElem(coin)
+
+	// Check features
+	if features, _ := internal.PolyMeta(p); !featuresSupported(features) {
+		return "", StatusErrUnsupported
+	}
+
+	// Calculate checksum
+	p.Encode()
+
+	return lang.Words[p.Coeff[0]], nil
+}
+
+// DiagnoseChecksum locates a single mistyped word in a phrase that fails
+// the checksum. words are the 16 words of the phrase in the given language.
+//
+// With one checksum word, every position has exactly one replacement that
+// satisfies the checksum, so the checksum alone cannot tell which word is
+// wrong. Replacements that would encode unsupported features or a birthday
+// in the future are discarded, and among the rest the one closest to the
+// typed word by edit distance is chosen.
+//
+// Returns the position of the wrong word and its correction. ok is false
+// if the words are invalid, the checksum already matches, or no single
+// correction is clearly the best.
+func DiagnoseChecksum(words []string, lang *lang.Language, coin Coin) (badIndex int, corrected string, ok bool) {
+	if len(words) != NumWords || coin > internal.GfMask {
+		return -1, "", false
+	}
+
+	p := &internal.GfPoly{}
+	defer wipePoly(p)
+	for i, word := range words {
+		idx := lang.FindWord(UTF8NFKDLazy(word))
+		if idx < 0 {
+			return -1, "", false
+		}
+		p.Coeff[i] = internal.GfElem(idx)
+	}
+	p.Coeff[internal.PolyNumCheckDigits] ^= internal.GfElem(coin)
+	if p.Check() {
+		return -1, "", false
+	}
+
+	now := birthdayEncode(uint64(time.Now().Unix()))
+	badIndex, bestDist, ties := -1, 0, 0
+	for i := 0; i < NumWords; i++ {
+		orig := p.Coeff[i]
+		p.Solve(i)
+		fixed := p.Coeff[i]
+		features, birthday := internal.PolyMeta(p)
+		p.Coeff[i] = orig
+		if !featuresSupported(features) || birthday > now {
+			continue
+		}
+
+		if i == internal.PolyNumCheckDigits {
+			orig ^= internal.GfElem(coin)
+			fixed ^= internal.GfElem(coin)
+		}
+		dist := lang.Distance(lang.Words[orig], lang.Words[fixed])
+		switch {
+		case badIndex < 0 || dist < bestDist:
+			badIndex, corrected, bestDist, ties = i, lang.Words[fixed], dist, 0
+		case dist == bestDist:
+			ties++
+		}
+	}
+
+	if badIndex < 0 || ties > 0 {
+		return -1, "", false
+	}
+	return badIndex, corrected, true
+}
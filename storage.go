@@ -0,0 +1,238 @@
+// Copyright (c) 2025-2026 complex (complex@ft.hn)
+// See LICENSE for licensing information
+
+package polyseed
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/complex-gh/polyseed_go/internal"
+)
+
+// seedJSON is the JSON representation of a seed
+type seedJSON struct {
+	Birthday     uint16 `json:"birthday"`
+	BirthdayTime string `json:"birthday_time"`
+	Features     uint8  `json:"features"`
+	Storage      string `json:"storage"`
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler. It returns the
+// serialized seed in the Storage format.
+func (s *Seed) MarshalBinary() ([]byte, error) {
+	var storage Storage
+	s.Store(&storage)
+	data := make([]byte, StorageSize)
+	copy(data, storage[:])
+	memzero(storage[:])
+	return data, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler. It loads a seed
+// serialized in the Storage format.
+//
+// The seed is filled in place, possibly inside another value, so SetAutoWipe
+// cannot attach a finalizer to it. Call Free when done.
+func (s *Seed) UnmarshalBinary(data []byte) error {
+	if len(data) != StorageSize {
+		return StatusErrFormat
+	}
+	var storage Storage
+	copy(storage[:], data)
+	seed, err := Load(&storage)
+	memzero(storage[:])
+	if err != nil {
+		return err
+	}
+	s.wipeKeys()
+	*s = *seed
+	seed.Free()
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler. The seed is emitted as the raw and
+// decoded birthday, the feature bits and the hex-encoded Storage blob. The
+// secret is only present inside the Storage blob, so the output must be
+// handled as sensitive.
+func (s *Seed) MarshalJSON() ([]byte, error) {
+	var storage Storage
+	s.Store(&storage)
+	defer memzero(storage[:])
+
+	return json.Marshal(seedJSON{
+		Birthday:     s.birthday,
+		BirthdayTime: s.BirthdayTime().Format(time.RFC3339),
+		Features:     s.features,
+		Storage:      storage.Hex(),
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler. The seed is loaded from the
+// Storage blob, the other fields are informational and ignored.
+func (s *Seed) UnmarshalJSON(data []byte) error {
+	var v seedJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	if v.Storage == "" {
+		return StatusErrFormat
+	}
+	blob, err := hex.DecodeString(v.Storage)
+	if err != nil {
+		return StatusErrFormat
+	}
+	defer memzero(blob)
+	return s.UnmarshalBinary(blob)
+}
+
+// Hex returns the storage blob as a 64-character lowercase hex string.
+// Like the blob itself, the string contains the secret and must be handled
+// as sensitive.
+func (storage *Storage) Hex() string {
+	return hex.EncodeToString(storage[:])
+}
+
+// ParseStorageHex parses a storage blob produced by Storage.Hex.
+//
+// Returns StatusErrFormat if the string is not exactly 64 hex characters.
+func ParseStorageHex(s string) (*Storage, error) {
+	if len(s) != hex.EncodedLen(StorageSize) {
+		return nil, StatusErrFormat
+	}
+	storage := &Storage{}
+	if _, err := hex.Decode(storage[:], []byte(s)); err != nil {
+		memzero(storage[:])
+		return nil, StatusErrFormat
+	}
+	return storage, nil
+}
+
+// Base64 returns the storage blob in unpadded URL-safe base64, which is
+// shorter than the hex form and suitable for QR codes and links.
+func (storage *Storage) Base64() string {
+	return base64.RawURLEncoding.EncodeToString(storage[:])
+}
+
+// ParseStorageBase64 parses a storage blob produced by Storage.Base64.
+//
+// Returns StatusErrFormat if the string is not valid base64 or does not
+// decode to exactly StorageSize bytes.
+func ParseStorageBase64(s string) (*Storage, error) {
+	blob, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil || len(blob) != StorageSize {
+		memzero(blob)
+		return nil, StatusErrFormat
+	}
+	storage := &Storage{}
+	copy(storage[:], blob)
+	memzero(blob)
+	return storage, nil
+}
+
+// Validate checks that the storage blob holds a valid seed, with the same
+// checks as Load, but without creating a Seed.
+func (storage *Storage) Validate() error {
+	d, err := loadData(storage)
+	if err != nil {
+		return err
+	}
+	putData(d)
+	return nil
+}
+
+// WriteTo implements io.WriterTo. It writes the seed in the Storage format,
+// StorageSize bytes in total.
+func (s *Seed) WriteTo(w io.Writer) (int64, error) {
+	var storage Storage
+	s.Store(&storage)
+	n, err := w.Write(storage[:])
+	memzero(storage[:])
+	return int64(n), err
+}
+
+// ReadSeed reads exactly StorageSize bytes from r and loads the seed they
+// contain, as written by Seed.WriteTo.
+//
+// Returns the error from r if fewer bytes are available, or the error from
+// Load if the data is not a valid seed.
+func ReadSeed(r io.Reader) (*Seed, error) {
+	var storage Storage
+	defer memzero(storage[:])
+	if _, err := io.ReadFull(r, storage[:]); err != nil {
+		return nil, err
+	}
+	return Load(&storage)
+}
+
+// Fingerprint returns a short identifier of the seed: the first 4 bytes of
+// the SHA-256 hash of its Storage blob, hex-encoded. It is stable across
+// encoding and decoding and does not reveal the secret, so it can be shown
+// to confirm which seed is in use.
+//
+// The blob includes the birthday and features, so seeds that share a
+// secret but differ in those have different fingerprints. Encrypting the
+// seed changes the fingerprint too.
+func (s *Seed) Fingerprint() string {
+	var storage Storage
+	s.Store(&storage)
+	sum := sha256.Sum256(storage[:])
+	memzero(storage[:])
+	return hex.EncodeToString(sum[:4])
+}
+
+// String implements fmt.Stringer. It describes the seed by its birthday
+// month, features, encryption flag and Fingerprint; the secret is never
+// included, so seeds can be logged with %v and %s safely. The receiver is
+// a value so that copies made with Snapshot are covered too.
+func (s Seed) String() string {
+	return fmt.Sprintf("Seed{birthday: %s, features: %#x, encrypted: %t, fingerprint: %s}",
+		s.BirthdayTime().Format("2006-01"), s.features&userFeaturesMask, s.IsEncrypted(), s.Fingerprint())
+}
+
+// GoString implements fmt.GoStringer, so that %#v does not print the
+// secret either
+func (s Seed) GoString() string {
+	return "polyseed." + s.String()
+}
+
+// CompactBytes returns the seed in a compact binary form of CompactSize
+// bytes, for transport in QR codes. The constant header and footer of the
+// Storage format are left out and the remaining fields are packed without
+// padding. The format is not interchangeable with Storage; use LoadCompact
+// to read it.
+func (s *Seed) CompactBytes() []byte {
+	var compact [CompactSize]byte
+	d := s.pooledData()
+	internal.DataStoreCompact(d, &compact)
+	putData(d)
+	data := make([]byte, CompactSize)
+	copy(data, compact[:])
+	memzero(compact[:])
+	return data
+}
+
+// LoadCompact loads a seed from the form returned by CompactBytes.
+//
+// Returns StatusErrFormat if the length is wrong, and the same errors as
+// Load if the data is not a valid seed.
+func LoadCompact(data []byte) (*Seed, error) {
+	if len(data) != CompactSize {
+		return nil, StatusErrFormat
+	}
+	var compact [CompactSize]byte
+	copy(compact[:], data)
+	d := getData()
+	internal.DataLoadCompact(&compact, d)
+	memzero(compact[:])
+	defer putData(d)
+	if err := verifyData(d); err != nil {
+		return nil, err
+	}
+	return seedFromData(d), nil
+}
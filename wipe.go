@@ -0,0 +1,47 @@
+// Copyright (c) 2025-2026 complex (complex@ft.hn)
+// See LICENSE for licensing information
+
+package polyseed
+
+import (
+	"runtime"
+	"sync/atomic"
+)
+
+// autoWipe is set when seeds are wiped automatically by the garbage collector
+var autoWipe atomic.Bool
+
+// SetAutoWipe enables or disables automatic wiping of seeds. When enabled,
+// every seed created afterward gets a finalizer that calls Free once the
+// seed is garbage collected. Seeds created before the call are not
+// affected.
+//
+// Finalizers are not guaranteed to run, and they only run some time after
+// the seed becomes unreachable, so calling Free explicitly is still
+// preferred. Copies made with Snapshot are plain values and are never
+// wiped automatically, and neither are seeds filled in place by
+// UnmarshalBinary or UnmarshalJSON.
+func SetAutoWipe(enabled bool) {
+	autoWipe.Store(enabled)
+}
+
+// finalizeHook is called with each seed wiped by its finalizer, so that
+// tests can observe the finalizer
+var finalizeHook atomic.Pointer[func(s *Seed)]
+
+// trackSeed attaches the wiping finalizer to a new seed if automatic
+// wiping is enabled
+func trackSeed(s *Seed) *Seed {
+	if autoWipe.Load() {
+		runtime.SetFinalizer(s, finalizeSeed)
+	}
+	return s
+}
+
+// finalizeSeed is the finalizer attached by trackSeed
+func finalizeSeed(s *Seed) {
+	s.Free()
+	if hook := finalizeHook.Load(); hook != nil {
+		(*hook)(s)
+	}
+}